@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_host  = "sfo-vcf01.sfo.rainpole.io"
+  credential_process = "vault-vcf-credentials sfo"
+}
+
+data "vcf_secret" "esxi_1" {
+  name = "sfo01-m01-esx01.sfo.rainpole.io"
+}
+
+resource "vcf_host" "esxi_1" {
+  fqdn              = "sfo01-m01-esx01.sfo.rainpole.io"
+  username          = "root"
+  password          = data.vcf_secret.esxi_1.value
+  network_pool_name = "sfo-m01-np01"
+  storage_type      = "VSAN"
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+    vsphere = {
+      source = "vmware/vsphere"
+    }
+  }
+}
+
+ephemeral "vcf_credentials" "vcenter" {
+  resource_name = "sfo-w01-vc01.sfo.rainpole.io"
+  resource_type = "VCENTER"
+  account_type  = "SYSTEM"
+}
+
+provider "vsphere" {
+  vsphere_server = ephemeral.vcf_credentials.vcenter.credentials[0].resource[0].name
+  user           = ephemeral.vcf_credentials.vcenter.credentials[0].user_name
+  password       = ephemeral.vcf_credentials.vcenter.credentials[0].password
+}
@@ -0,0 +1,101 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vcf-sdk-go/installer"
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+// ApiError is an error returned by the SDDC Manager or installer API. It keeps the error code,
+// remediation, causes and the reference token of the error, which identifies it in the service
+// logs and should be quoted when contacting support.
+//
+// Use errors.As to retrieve an ApiError from an error chain.
+type ApiError struct {
+	Err vcf.Error
+}
+
+// NewApiError wraps an error returned by the SDDC Manager API.
+func NewApiError(err *vcf.Error) error {
+	if err == nil {
+		return &ApiError{}
+	}
+	return &ApiError{Err: *err}
+}
+
+// NewInstallerApiError wraps an error returned by the installer API.
+func NewInstallerApiError(err *installer.Error) error {
+	if err == nil {
+		return &ApiError{}
+	}
+	return &ApiError{Err: ConvertToVcfError(*err)}
+}
+
+func (e *ApiError) Error() string {
+	if message := stringValue(e.Err.Message); message != "" {
+		return message
+	}
+	if errorCode := stringValue(e.Err.ErrorCode); errorCode != "" {
+		return fmt.Sprintf("the API returned error %s", errorCode)
+	}
+	return "the API returned an unknown error"
+}
+
+// ErrorCode returns the error code, e.g. "VCF_ERROR_DOMAIN_NOT_FOUND".
+func (e *ApiError) ErrorCode() string {
+	return stringValue(e.Err.ErrorCode)
+}
+
+// ReferenceToken returns the token which identifies the error in the service logs.
+func (e *ApiError) ReferenceToken() string {
+	return stringValue(e.Err.ReferenceToken)
+}
+
+// Detail describes the error beyond its message: the remediation, the causes and nested errors,
+// the error code and the reference token.
+func (e *ApiError) Detail() string {
+	var builder strings.Builder
+	writeErrorDetail(&builder, e.Err, "")
+
+	if errorCode := e.ErrorCode(); errorCode != "" {
+		fmt.Fprintf(&builder, "Error code: %s\n", errorCode)
+	}
+	if referenceToken := e.ReferenceToken(); referenceToken != "" {
+		fmt.Fprintf(&builder, "Reference token: %s (look for it in the service logs or quote it to support)\n",
+			referenceToken)
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// writeErrorDetail writes the remediation and the causes of an error, followed by its nested
+// errors which are indented one level deeper.
+func writeErrorDetail(builder *strings.Builder, err vcf.Error, indent string) {
+	if remediation := stringValue(err.RemediationMessage); remediation != "" {
+		fmt.Fprintf(builder, "%sRemediation: %s\n", indent, remediation)
+	}
+	if remediationUrl := stringValue(err.RemediationUrl); remediationUrl != "" {
+		fmt.Fprintf(builder, "%sSee %s\n", indent, remediationUrl)
+	}
+	if err.Causes != nil {
+		for _, cause := range *err.Causes {
+			if message := stringValue(cause.Message); message != "" {
+				fmt.Fprintf(builder, "%sCause: %s\n", indent, message)
+			}
+		}
+	}
+	if err.NestedErrors != nil {
+		for _, nested := range *err.NestedErrors {
+			fmt.Fprintf(builder, "%s- %s\n", indent, (&ApiError{Err: nested}).Error())
+			writeErrorDetail(builder, nested, indent+"  ")
+			if referenceToken := stringValue(nested.ReferenceToken); referenceToken != "" {
+				fmt.Fprintf(builder, "%s  Reference token: %s\n", indent, referenceToken)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/installer"
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+func TestApiError(t *testing.T) {
+	err := fmt.Errorf("failed to create domain: %w", NewApiError(&vcf.Error{
+		ErrorCode:          ptr("VCF_ERROR_DOMAIN_CREATE_FAILED"),
+		Message:            ptr("Domain creation failed"),
+		RemediationMessage: ptr("Fix the domain spec"),
+		ReferenceToken:     ptr("K1FMMB"),
+		Causes:             &[]vcf.ErrorCause{{Message: ptr("vCenter Server is not reachable")}},
+		NestedErrors: &[]vcf.Error{{
+			Message:        ptr("Host esxi-1 is not reachable"),
+			ReferenceToken: ptr("P6DJ1V"),
+		}},
+	}))
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("expected an ApiError", err)
+	}
+	if apiErr.Error() != "Domain creation failed" || apiErr.ErrorCode() != "VCF_ERROR_DOMAIN_CREATE_FAILED" {
+		t.Fatal("unexpected error", apiErr)
+	}
+	for _, expected := range []string{"Fix the domain spec", "vCenter Server is not reachable",
+		"Host esxi-1 is not reachable", "P6DJ1V", "VCF_ERROR_DOMAIN_CREATE_FAILED", "Reference token: K1FMMB"} {
+		if !strings.Contains(apiErr.Detail(), expected) {
+			t.Errorf("expected %q in the detail:\n%s", expected, apiErr.Detail())
+		}
+	}
+}
+
+func TestApiError_withoutMessage(t *testing.T) {
+	if message := NewApiError(nil).Error(); message != "the API returned an unknown error" {
+		t.Fatal("unexpected message", message)
+	}
+	err := NewInstallerApiError(&installer.Error{ErrorCode: ptr("INSTALLER_ERROR")})
+	if !strings.Contains(err.Error(), "INSTALLER_ERROR") {
+		t.Fatal("unexpected message", err)
+	}
+}
@@ -0,0 +1,229 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// redacted replaces the values of sensitive fields in the audit log.
+	redacted = "REDACTED"
+	// maxAuditedBodySize is the size up to which bodies are written to the audit log.
+	maxAuditedBodySize = 1 << 20
+)
+
+// sensitiveKeys are the parts of the JSON keys whose values are redacted in the audit log. Keys
+// are compared in lower case without "_" and "-", so "apiKey" and "api_key" are both redacted.
+var sensitiveKeys = []string{"password", "passphrase", "secret", "token", "apikey", "privatekey"}
+
+// auditedKeys are the keys which contain a sensitive part but are not secret. The reference
+// token of an error identifies it in the logs of SDDC Manager and has to be quoted to support.
+var auditedKeys = []string{"referencetoken"}
+
+// auditEntry is a line of the audit log, written for every request sent to the server.
+type auditEntry struct {
+	Time         string          `json:"time"`
+	Method       string          `json:"method"`
+	Url          string          `json:"url"`
+	Status       int             `json:"status,omitempty"`
+	LatencyMs    int64           `json:"latency_ms"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// auditLog is a file to which audit entries are appended as JSON lines.
+type auditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// auditLogs holds the audit logs of the process by path, so that the clients which write to
+// the same file do not interleave their lines.
+var auditLogs = struct {
+	mu   sync.Mutex
+	logs map[string]*auditLog
+}{
+	logs: make(map[string]*auditLog),
+}
+
+func openAuditLog(path string) (*auditLog, error) {
+	auditLogs.mu.Lock()
+	defer auditLogs.mu.Unlock()
+
+	if log, ok := auditLogs.logs[path]; ok {
+		return log, nil
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log: %w", err)
+	}
+	log := &auditLog{file: file}
+	auditLogs.logs[path] = log
+	return log, nil
+}
+
+func (log *auditLog) write(entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	_, err = log.file.Write(append(line, '\n'))
+	return err
+}
+
+// auditTransport is a http.RoundTripper which writes every request and its response to an
+// audit log, with the values of sensitive fields redacted. Retried requests are written once
+// per attempt.
+type auditTransport struct {
+	next http.RoundTripper
+	log  *auditLog
+}
+
+func newAuditTransport(next http.RoundTripper, log *auditLog) *auditTransport {
+	return &auditTransport{
+		next: next,
+		log:  log,
+	}
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := auditEntry{
+		Time:   time.Now().UTC().Format(time.RFC3339Nano),
+		Method: req.Method,
+		Url:    req.URL.String(),
+	}
+
+	req, requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	entry.RequestBody = redactBody(requestBody, isTokenRequest(req))
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	if err == nil {
+		var responseBody []byte
+		responseBody, err = readResponseBody(res)
+		entry.Status = res.StatusCode
+		entry.ResponseBody = redactBody(responseBody, isTokenRequest(req))
+	}
+	entry.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if logErr := t.log.write(entry); logErr != nil {
+		tflog.Warn(req.Context(), fmt.Sprintf("failed to write to the audit log: %s", logErr))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// readRequestBody returns the body of a request and a request which can still be sent with it.
+func readRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() { _ = body.Close() }()
+		data, err := io.ReadAll(body)
+		return req, data, err
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(data))
+	return clone, data, nil
+}
+
+// readResponseBody reads the body of a response and replaces it with the data which has been read.
+func readResponseBody(res *http.Response) ([]byte, error) {
+	if res.Body == nil || res.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// redactBody returns the JSON body with the values of sensitive fields redacted. Bodies which
+// are too large or not JSON are omitted, they cannot be redacted reliably. The bodies of token
+// requests are strings such as a refresh token, so all strings are redacted in them.
+func redactBody(body []byte, redactStrings bool) json.RawMessage {
+	if len(body) == 0 || len(body) > maxAuditedBodySize {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil
+	}
+	data, err := json.Marshal(redactValue(value, redactStrings))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func redactValue(value interface{}, redactStrings bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitiveKey(key) {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(field, redactStrings)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item, redactStrings)
+		}
+	case string:
+		if redactStrings {
+			return redacted
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	if slices.Contains(auditedKeys, key) {
+		return false
+	}
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
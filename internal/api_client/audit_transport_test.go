@@ -0,0 +1,103 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+func readAuditLog(t *testing.T, path string) []auditEntry {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal("expected the audit log to consist of JSON lines", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditTransport(t *testing.T) {
+	server := fake_server.NewServer(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	client := newTestSddcManagerClient(t, server, WithRetry(0, 0), WithAuditLog(path))
+	server.AddHost(vcf.Host{Id: ptr("host-1"), Fqdn: ptr("esxi-1.vsphere.local")})
+	server.FailRequests(http.MethodGet, "/v1/hosts/host-2", 1, http.StatusNotFound,
+		vcf.Error{Message: ptr("Host not found")})
+
+	if _, err := client.ApiClient.GetHostsWithResponse(context.Background(), nil); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if _, err := client.ApiClient.GetHostWithResponse(context.Background(), "host-2"); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 3 {
+		t.Fatalf("expected the login and both requests to be written, got %d entries", len(entries))
+	}
+
+	login := entries[0]
+	if login.Method != http.MethodPost || !strings.HasSuffix(login.Url, "/v1/tokens") || login.Status != http.StatusOK {
+		t.Fatal("unexpected login entry", login)
+	}
+	if strings.Contains(string(login.RequestBody), fake_server.Password) || !strings.Contains(string(login.RequestBody), redacted) {
+		t.Fatal("expected the password to be redacted", string(login.RequestBody))
+	}
+	if !strings.Contains(string(login.ResponseBody), `"accessToken":"REDACTED"`) {
+		t.Fatal("expected the tokens to be redacted", string(login.ResponseBody))
+	}
+
+	if hosts := entries[1]; hosts.Status != http.StatusOK || !strings.Contains(string(hosts.ResponseBody), "esxi-1.vsphere.local") {
+		t.Fatal("unexpected entry", hosts)
+	}
+	if host := entries[2]; host.Status != http.StatusNotFound || !strings.Contains(string(host.ResponseBody), "Host not found") {
+		t.Fatal("unexpected entry", host)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`{"name":"esxi-1","credentials":{"username":"root","password":"secret"},` +
+		`"apiKey":"key","specs":[{"ssh_passphrase":"secret","vcenter_root_password":"secret"}]}`)
+
+	redactedBody := string(redactBody(body, false))
+	if strings.Contains(redactedBody, "secret") || !strings.Contains(redactedBody, `"apiKey":"REDACTED"`) {
+		t.Fatal("expected the sensitive fields to be redacted", redactedBody)
+	}
+	if !strings.Contains(redactedBody, `"name":"esxi-1"`) || !strings.Contains(redactedBody, `"username":"root"`) {
+		t.Fatal("expected the other fields to be kept", redactedBody)
+	}
+
+	errorBody := string(redactBody([]byte(`{"message":"Host not found","referenceToken":"K1FMMB"}`), false))
+	if !strings.Contains(errorBody, `"referenceToken":"K1FMMB"`) {
+		t.Fatal("expected the reference token of the error to be kept", errorBody)
+	}
+
+	if refreshToken := string(redactBody([]byte(`"refresh-token"`), true)); refreshToken != `"REDACTED"` {
+		t.Fatal("expected the string to be redacted", refreshToken)
+	}
+	if notJson := redactBody([]byte("password=secret"), false); notJson != nil {
+		t.Fatal("expected a body which is not JSON to be omitted", string(notJson))
+	}
+}
@@ -0,0 +1,152 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// cachedCollections are the lists of the inventory which are read by many resources, by path.
+var cachedCollections = map[string]bool{
+	"/v1/domains":       true,
+	"/v1/clusters":      true,
+	"/v1/hosts":         true,
+	"/v1/network-pools": true,
+	"/v1/edge-clusters": true,
+}
+
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// cacheTransport is a http.RoundTripper which caches the responses of the inventory collections
+// and of their elements, e.g. GET /v1/hosts/{id}, for the lifetime of the client. Every response
+// is cached by its own URL, so an element is always read as SDDC Manager returns it and never
+// taken from the list of its collection.
+//
+// The cache is cleared by every request which may change the inventory and whenever a task is
+// found to have finished, as tasks change the inventory while they run.
+type cacheTransport struct {
+	next http.RoundTripper
+
+	mu         sync.Mutex
+	generation uint64
+	responses  map[string]cachedResponse
+}
+
+func newCacheTransport(next http.RoundTripper) *cacheTransport {
+	return &cacheTransport{
+		next:      next,
+		responses: make(map[string]cachedResponse),
+	}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		res, err := t.next.RoundTrip(req)
+		if !isTokenRequest(req) {
+			t.invalidate()
+		}
+		return res, err
+	}
+
+	if strings.HasPrefix(req.URL.Path, "/v1/tasks/") {
+		return t.getTask(req)
+	}
+	if !isCachedPath(req.URL.Path) {
+		return t.next.RoundTrip(req)
+	}
+	return t.getCached(req)
+}
+
+// invalidate clears the cache. Requests in flight do not add their responses to the cache.
+func (t *cacheTransport) invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.generation++
+	clear(t.responses)
+}
+
+// getTask retrieves a task and clears the cache if the task has finished.
+func (t *cacheTransport) getTask(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+	body, err := readResponseBody(res)
+	if err != nil {
+		return nil, err
+	}
+	var task struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal(body, &task) != nil || sddcManagerTaskState(task.Status).finished() {
+		t.invalidate()
+	}
+	return res, nil
+}
+
+// getCached returns the cached response of a request, or sends the request and caches its
+// response.
+func (t *cacheTransport) getCached(req *http.Request) (*http.Response, error) {
+	key := req.URL.RequestURI()
+	t.mu.Lock()
+	cached, ok := t.responses[key]
+	generation := t.generation
+	t.mu.Unlock()
+	if ok {
+		return newCachedResponse(req, cached), nil
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+	body, err := readResponseBody(res)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	if t.generation == generation {
+		t.responses[key] = cachedResponse{statusCode: res.StatusCode, header: res.Header.Clone(), body: body}
+	}
+	t.mu.Unlock()
+	return res, nil
+}
+
+// isCachedPath reports whether a path is that of an inventory collection or of one of its
+// elements.
+func isCachedPath(path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	if cachedCollections[path] {
+		return true
+	}
+	i := strings.LastIndex(path, "/")
+	return i >= 0 && cachedCollections[path[:i]]
+}
+
+func newCachedResponse(req *http.Request, cached cachedResponse) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.statusCode, http.StatusText(cached.statusCode)),
+		StatusCode:    cached.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,108 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+func TestCacheTransport_elementsByUrl(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithInventoryCache(true))
+	addTestHosts(server, 2)
+
+	if _, err := client.ApiClient.GetHostsWithResponse(context.Background(), nil); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	for range 2 {
+		res, err := client.ApiClient.GetHostWithResponse(context.Background(), "host-1")
+		if err != nil {
+			t.Fatal("received an unexpected error", err)
+		}
+		if res.JSON200 == nil || *res.JSON200.Fqdn != "esxi-1.vsphere.local" {
+			t.Fatal("unexpected host", res.StatusCode(), string(res.Body))
+		}
+	}
+
+	if requests := server.RequestCount(http.MethodGet, "/v1/hosts/host-1"); requests != 1 {
+		t.Fatalf("expected the host to be retrieved on its own once, got %d requests", requests)
+	}
+}
+
+func TestCacheTransport_unknownElement(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithInventoryCache(true))
+	addTestHosts(server, 2)
+
+	for range 2 {
+		res, err := client.ApiClient.GetHostWithResponse(context.Background(), "host-3")
+		if err != nil {
+			t.Fatal("received an unexpected error", err)
+		}
+		if res.StatusCode() != http.StatusNotFound {
+			t.Fatal("expected the host not to be found", res.StatusCode())
+		}
+	}
+	if requests := server.RequestCount(http.MethodGet, "/v1/hosts/host-3"); requests != 2 {
+		t.Fatalf("expected the missing host not to be cached, got %d requests", requests)
+	}
+}
+
+func TestCacheTransport_invalidatedByRequest(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithInventoryCache(true))
+	poolId := server.AddNetworkPool(vcf.NetworkPool{Name: "pool-1"})
+
+	getNetworkPools := func() []vcf.NetworkPool {
+		res, err := client.ApiClient.GetNetworkPoolWithResponse(context.Background())
+		if err != nil || res.JSON200 == nil || res.JSON200.Elements == nil {
+			t.Fatal("unexpected response", err)
+		}
+		return *res.JSON200.Elements
+	}
+
+	getNetworkPools()
+	getNetworkPools()
+	if requests := server.RequestCount(http.MethodGet, "/v1/network-pools"); requests != 1 {
+		t.Fatalf("expected the network pools to be retrieved once, got %d requests", requests)
+	}
+
+	if _, err := client.ApiClient.DeleteNetworkPoolWithResponse(context.Background(), poolId, nil); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if pools := getNetworkPools(); len(pools) != 0 {
+		t.Fatal("expected the deleted network pool not to be returned from the cache", pools)
+	}
+}
+
+func TestCacheTransport_invalidatedByTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithInventoryCache(true))
+	taskId := server.AddTask(vcf.Task{}, fake_server.TaskStatusInProgress, fake_server.TaskStatusSuccessful)
+
+	getDomains(t, client)
+	if _, err := client.ApiClient.GetTaskWithResponse(context.Background(), taskId); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	getDomains(t, client)
+	if requests := server.RequestCount(http.MethodGet, "/v1/domains"); requests != 1 {
+		t.Fatalf("expected the cache to be kept while the task is running, got %d requests", requests)
+	}
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, taskId, testPollingInterval)
+	if err := tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	getDomains(t, client)
+	if requests := server.RequestCount(http.MethodGet, "/v1/domains"); requests != 2 {
+		t.Fatalf("expected the domains to be retrieved again after the task, got %d requests", requests)
+	}
+}
@@ -0,0 +1,340 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed idempotent request is retried by default.
+	DefaultMaxRetries = 4
+	// DefaultRetryMaxBackoff is the default upper bound of the delay between two retries.
+	DefaultRetryMaxBackoff = 30 * time.Second
+
+	// DefaultConnectTimeout is the default limit for establishing a connection, including the TLS handshake.
+	DefaultConnectTimeout = 30 * time.Second
+	// DefaultRequestTimeout is the default limit for a request, from sending it until its response
+	// has been read.
+	DefaultRequestTimeout = 5 * time.Minute
+	// DefaultKeepAlive is the default interval between TCP keep-alive probes of a connection.
+	DefaultKeepAlive = 30 * time.Second
+	// DefaultIdleConnectionTimeout is how long an idle connection is kept open for reuse by default.
+	DefaultIdleConnectionTimeout = 90 * time.Second
+
+	// DefaultMaxRequestsPerSecond is the default rate of requests sent to an endpoint. SDDC Manager
+	// starts rejecting requests with 429 or 5xx responses when it receives many more.
+	DefaultMaxRequestsPerSecond = 10
+	// DefaultMaxRequestBurst is the default number of requests which may be sent at once before
+	// the rate limit applies, so requests are evenly spread by default.
+	DefaultMaxRequestBurst = 1
+	// DefaultMaxConcurrentRequests is the default number of requests in flight to an endpoint.
+	DefaultMaxConcurrentRequests = 6
+
+	// DefaultLockConflictRetryInterval is how long a request rejected because another operation
+	// is in progress waits by default before it is sent again.
+	DefaultLockConflictRetryInterval = 30 * time.Second
+	// DefaultLockConflictTimeout is how long a request rejected because another operation is in
+	// progress is retried by default.
+	DefaultLockConflictTimeout = time.Hour
+)
+
+// clientConfig holds the settings of the HTTP client shared by SddcManagerClient and InstallerClient.
+type clientConfig struct {
+	maxRetries       int
+	retryMaxBackoff  time.Duration
+	caCertificate    string
+	serverThumbprint string
+
+	cancelTasksOnInterrupt bool
+	// How often the trackers created by the client poll their tasks, 0 keeps the interval of each kind of task
+	taskPollingInterval time.Duration
+
+	// Transport settings, a proxyUrl of "" uses the proxy of the environment
+	proxyUrl              string
+	connectTimeout        time.Duration
+	requestTimeout        time.Duration
+	keepAlive             time.Duration
+	idleConnectionTimeout time.Duration
+
+	// Limits of the requests sent to the endpoint, 0 disables a limit
+	maxRequestsPerSecond  int
+	maxRequestBurst       int
+	maxConcurrentRequests int
+
+	// How requests rejected because another operation is in progress are retried, see
+	// lockRetryTransport. A lockConflictTimeout of 0 disables the retries.
+	lockConflictRetryInterval time.Duration
+	lockConflictTimeout       time.Duration
+
+	// Whether the inventory collections are cached, see cacheTransport
+	inventoryCache bool
+
+	// The path of the audit log of the requests, not written if empty
+	auditLogPath string
+
+	// Alternatives to the username and password of SDDC Manager
+	apiKey      string
+	accessToken string
+}
+
+// ClientOption customizes the HTTP client used to communicate with SDDC Manager or the installer.
+type ClientOption func(*clientConfig)
+
+// WithRetry sets how many times a request that failed with a transient error is retried and
+// the maximum delay between two attempts. A maxRetries of 0 disables retries.
+func WithRetry(maxRetries int, maxBackoff time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.maxRetries = maxRetries
+		config.retryMaxBackoff = maxBackoff
+	}
+}
+
+// WithCaCertificate makes the client trust the given CA in addition to the system roots.
+// The certificate is either PEM encoded or the path of a PEM file.
+func WithCaCertificate(caCertificate string) ClientOption {
+	return func(config *clientConfig) {
+		config.caCertificate = caCertificate
+	}
+}
+
+// WithServerThumbprint pins the SHA-256 thumbprint of the server certificate. A server which
+// presents a certificate with this thumbprint is trusted even if it is not signed by a known CA.
+func WithServerThumbprint(thumbprint string) ClientOption {
+	return func(config *clientConfig) {
+		config.serverThumbprint = thumbprint
+	}
+}
+
+// WithCancelTasksOnInterrupt sets whether a task is cancelled on SDDC Manager when waiting for it
+// is interrupted, e.g. because the resource timeout has expired. By default the task is left running.
+func WithCancelTasksOnInterrupt(cancel bool) ClientOption {
+	return func(config *clientConfig) {
+		config.cancelTasksOnInterrupt = cancel
+	}
+}
+
+// WithTaskPollingInterval sets how often the task trackers created by the client poll their
+// tasks, in place of the interval of each kind of task. By default tasks are polled every 20
+// seconds, installer validations every 10 seconds and host commissions every 5 seconds.
+func WithTaskPollingInterval(interval time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.taskPollingInterval = interval
+	}
+}
+
+// WithApiKey makes the client log in to SDDC Manager with the API key of a service account
+// instead of a username and password.
+func WithApiKey(apiKey string) ClientOption {
+	return func(config *clientConfig) {
+		config.apiKey = apiKey
+	}
+}
+
+// WithAccessToken makes the client authenticate to SDDC Manager with an access token issued
+// beforehand instead of logging in. The token cannot be renewed, requests fail once it expires.
+func WithAccessToken(accessToken string) ClientOption {
+	return func(config *clientConfig) {
+		config.accessToken = accessToken
+	}
+}
+
+// WithProxyUrl sends the requests through the given HTTP proxy, except for the hosts excluded
+// by the NO_PROXY environment variable. By default the proxy is taken from the HTTPS_PROXY and
+// NO_PROXY environment variables.
+func WithProxyUrl(proxyUrl string) ClientOption {
+	return func(config *clientConfig) {
+		config.proxyUrl = proxyUrl
+	}
+}
+
+// WithTimeouts sets the limit for establishing a connection, including the TLS handshake, and
+// for a request, from sending it until its response has been read. Every attempt of a retried
+// request has the full request timeout. A timeout of 0 disables the limit.
+func WithTimeouts(connectTimeout, requestTimeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.connectTimeout = connectTimeout
+		config.requestTimeout = requestTimeout
+	}
+}
+
+// WithKeepAlive sets the interval between TCP keep-alive probes of a connection and how long an
+// idle connection is kept open for reuse. A keepAlive of 0 disables keep-alive, every request
+// then uses a new connection.
+func WithKeepAlive(keepAlive, idleConnectionTimeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.keepAlive = keepAlive
+		config.idleConnectionTimeout = idleConnectionTimeout
+	}
+}
+
+// WithRequestLimits limits the rate of the requests sent to the endpoint and how many of them
+// are in flight at the same time. Requests wait until they are allowed. A limit of 0 disables it.
+// The size of the bursts allowed by the rate limit is set with WithRequestBurst.
+func WithRequestLimits(maxRequestsPerSecond, maxConcurrentRequests int) ClientOption {
+	return func(config *clientConfig) {
+		config.maxRequestsPerSecond = maxRequestsPerSecond
+		config.maxConcurrentRequests = maxConcurrentRequests
+	}
+}
+
+// WithRequestBurst sets how many requests may be sent at once before the rate limit set with
+// WithRequestLimits applies. A burst of 1 spreads the requests evenly over each second.
+func WithRequestBurst(burst int) ClientOption {
+	return func(config *clientConfig) {
+		config.maxRequestBurst = burst
+	}
+}
+
+// WithLockConflictRetry sets how long the requests which are rejected because another operation
+// holds a lock on the same resources wait before they are sent again, and for how long they are
+// retried. A timeout of 0 disables the retries.
+func WithLockConflictRetry(interval, timeout time.Duration) ClientOption {
+	return func(config *clientConfig) {
+		config.lockConflictRetryInterval = interval
+		config.lockConflictTimeout = timeout
+	}
+}
+
+// WithInventoryCache sets whether the domains, clusters, hosts, network pools and edge clusters
+// read from the endpoint are cached until a request or a task changes the inventory. The cache
+// is enabled by default.
+func WithInventoryCache(enabled bool) ClientOption {
+	return func(config *clientConfig) {
+		config.inventoryCache = enabled
+	}
+}
+
+// WithAuditLog writes every request and response to the file at the given path as JSON lines,
+// with the values of passwords, secrets, tokens and API keys redacted.
+func WithAuditLog(path string) ClientOption {
+	return func(config *clientConfig) {
+		config.auditLogPath = path
+	}
+}
+
+// getTaskPollingInterval returns the interval at which a task is polled, given the interval of its kind.
+func (config clientConfig) getTaskPollingInterval(pollingInterval time.Duration) time.Duration {
+	if config.taskPollingInterval > 0 {
+		return config.taskPollingInterval
+	}
+	return pollingInterval
+}
+
+func newClientConfig(opts []ClientOption) clientConfig {
+	config := clientConfig{
+		maxRetries:            DefaultMaxRetries,
+		retryMaxBackoff:       DefaultRetryMaxBackoff,
+		connectTimeout:        DefaultConnectTimeout,
+		requestTimeout:        DefaultRequestTimeout,
+		keepAlive:             DefaultKeepAlive,
+		idleConnectionTimeout: DefaultIdleConnectionTimeout,
+		maxRequestsPerSecond:  DefaultMaxRequestsPerSecond,
+		maxRequestBurst:       DefaultMaxRequestBurst,
+		maxConcurrentRequests: DefaultMaxConcurrentRequests,
+		inventoryCache:        true,
+
+		lockConflictRetryInterval: DefaultLockConflictRetryInterval,
+		lockConflictTimeout:       DefaultLockConflictTimeout,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// newHttpClient returns the HTTP client of an API client. Requests are limited by the request
+// timeout, authenticated with the access token of the given token manager, retried when they fail with a transient error or are
+// rejected because of a lock conflict, limited in rate and concurrency, traced and written to the
+// audit log if any. The responses of the inventory collections are cached unless disabled.
+func (config clientConfig) newHttpClient(allowUnverifiedTls bool, tokens *tokenManager) (*http.Client, error) {
+	transport, err := config.newTransport(allowUnverifiedTls)
+	if err != nil {
+		return nil, err
+	}
+	tr := newTimeoutTransport(transport, config.requestTimeout)
+	if config.auditLogPath != "" {
+		log, err := openAuditLog(config.auditLogPath)
+		if err != nil {
+			return nil, err
+		}
+		tr = newAuditTransport(tr, log)
+	}
+	tr = newLimitTransport(tr, config.maxRequestsPerSecond, config.maxRequestBurst, config.maxConcurrentRequests)
+	tr = newAuthTransport(newRetryTransport(tr, config.maxRetries, config.retryMaxBackoff), tokens)
+	if config.lockConflictTimeout > 0 {
+		tr = newLockRetryTransport(tr, config.lockConflictRetryInterval, config.lockConflictTimeout)
+	}
+	if config.inventoryCache {
+		tr = newCacheTransport(tr)
+	}
+	return &http.Client{
+		Transport: newTracingTransport(tr),
+	}, nil
+}
+
+// newTransport returns the transport which connects to the server, through the proxy if any.
+func (config clientConfig) newTransport(allowUnverifiedTls bool) (*http.Transport, error) {
+	tlsConfig, err := config.newTlsConfig(allowUnverifiedTls)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := config.newProxyFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   config.connectTimeout,
+		KeepAlive: config.keepAlive,
+	}
+	if config.keepAlive == 0 {
+		// A KeepAlive of 0 enables the default interval of the dialer
+		dialer.KeepAlive = -1
+	}
+	return &http.Transport{
+		Proxy:               proxy,
+		DialContext:         dialer.DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: config.connectTimeout,
+		DisableKeepAlives:   config.keepAlive == 0,
+		IdleConnTimeout:     config.idleConnectionTimeout,
+	}, nil
+}
+
+func (config clientConfig) newProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if config.proxyUrl == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	proxyUrl, err := url.Parse(config.proxyUrl)
+	if err != nil || proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+		// The URL may contain the credentials of the proxy, it must not be part of the error
+		return nil, errors.New("invalid proxy URL, expected e.g. http://proxy.example.com:3128")
+	}
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  config.proxyUrl,
+		HTTPSProxy: config.proxyUrl,
+		NoProxy:    getEnvAny("NO_PROXY", "no_proxy"),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+func getEnvAny(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getProxy(t *testing.T, opts []ClientOption, target string) string {
+	tr, err := newClientConfig(opts).newTransport(false)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyUrl, err := tr.Proxy(req)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if proxyUrl == nil {
+		return ""
+	}
+	return proxyUrl.String()
+}
+
+func TestTransport_proxyUrl(t *testing.T) {
+	t.Setenv("NO_PROXY", "installer.vsphere.local")
+
+	opts := []ClientOption{WithProxyUrl("http://proxy.vsphere.local:3128")}
+	if proxy := getProxy(t, opts, "https://sddc-manager.vsphere.local/v1/tokens"); proxy != "http://proxy.vsphere.local:3128" {
+		t.Fatal("expected the request to be sent through the proxy, got", proxy)
+	}
+	if proxy := getProxy(t, opts, "https://installer.vsphere.local/v1/tokens"); proxy != "" {
+		t.Fatal("expected the host in NO_PROXY to be reached directly, got", proxy)
+	}
+}
+
+func TestTransport_invalidProxyUrl(t *testing.T) {
+	_, err := newClientConfig([]ClientOption{WithProxyUrl("user:secret@proxy.vsphere.local")}).newTransport(false)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Fatal("expected the error not to contain the proxy URL", err)
+	}
+}
+
+func TestTransport_disableKeepAlive(t *testing.T) {
+	tr, err := newClientConfig([]ClientOption{WithKeepAlive(0, time.Minute)}).newTransport(false)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if !tr.DisableKeepAlives {
+		t.Fatal("expected keep-alive to be disabled")
+	}
+}
+
+func TestClientConfig_requestBurst(t *testing.T) {
+	if burst := newClientConfig(nil).maxRequestBurst; burst != 1 {
+		t.Fatal("expected the requests to be spread evenly by default, got a burst of", burst)
+	}
+	if burst := newClientConfig([]ClientOption{WithRequestBurst(5)}).maxRequestBurst; burst != 5 {
+		t.Fatal("expected a burst of 5, got", burst)
+	}
+}
@@ -0,0 +1,106 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vmware/terraform-provider-vcf/internal/credential_source"
+)
+
+// clientKey identifies the clients which can be shared: clients of the same endpoint, with the
+// same credentials and the same settings.
+type clientKey struct {
+	url                string
+	username           string
+	password           string
+	allowUnverifiedTls bool
+	config             clientConfig
+}
+
+// clientRegistry holds the clients of the process. The SDK v2 and the framework provider are
+// served by the same process and configured with the same settings, so they share a client,
+// its access token and its connection pool rather than logging in twice. They also share the
+// credentials retrieved for them, so that a credential process is only run once.
+var clientRegistry = struct {
+	mu          sync.Mutex
+	sddcManager map[clientKey]*SddcManagerClient
+	installer   map[clientKey]*InstallerClient
+	credentials map[credential_source.Source]*credential_source.Credentials
+}{
+	sddcManager: make(map[clientKey]*SddcManagerClient),
+	installer:   make(map[clientKey]*InstallerClient),
+	credentials: make(map[credential_source.Source]*credential_source.Credentials),
+}
+
+// LoadCredentials returns the credentials of the process retrieved from the given source. The
+// credentials of a source are retrieved on first use and reused afterward.
+func LoadCredentials(ctx context.Context, source credential_source.Source) (*credential_source.Credentials, error) {
+	clientRegistry.mu.Lock()
+	defer clientRegistry.mu.Unlock()
+
+	if credentials, ok := clientRegistry.credentials[source]; ok {
+		return credentials, nil
+	}
+	credentials, err := credential_source.Load(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	clientRegistry.credentials[source] = credentials
+	return credentials, nil
+}
+
+// GetSddcManagerClient returns the initialized SDDC Manager client of the process for the given
+// endpoint, credentials and settings. The client is created on first use, see Init.
+func GetSddcManagerClient(username, password, url, providerVersion string, allowUnverifiedTls bool,
+	opts ...ClientOption) (*SddcManagerClient, error) {
+	key := clientKey{
+		url:                url,
+		username:           username,
+		password:           password,
+		allowUnverifiedTls: allowUnverifiedTls,
+		config:             newClientConfig(opts),
+	}
+
+	clientRegistry.mu.Lock()
+	defer clientRegistry.mu.Unlock()
+
+	if client, ok := clientRegistry.sddcManager[key]; ok {
+		return client, nil
+	}
+	client := NewSddcManagerClient(username, password, url, providerVersion, allowUnverifiedTls, opts...)
+	if err := client.Init(); err != nil {
+		return nil, err
+	}
+	clientRegistry.sddcManager[key] = client
+	return client, nil
+}
+
+// GetInstallerClient returns the initialized installer client of the process for the given
+// endpoint, credentials and settings. The client is created on first use, see Init.
+func GetInstallerClient(username, password, url string, allowUnverifiedTls bool,
+	opts ...ClientOption) (*InstallerClient, error) {
+	key := clientKey{
+		url:                url,
+		username:           username,
+		password:           password,
+		allowUnverifiedTls: allowUnverifiedTls,
+		config:             newClientConfig(opts),
+	}
+
+	clientRegistry.mu.Lock()
+	defer clientRegistry.mu.Unlock()
+
+	if client, ok := clientRegistry.installer[key]; ok {
+		return client, nil
+	}
+	client := NewInstallerClient(username, password, url, allowUnverifiedTls, opts...)
+	if err := client.Init(); err != nil {
+		return nil, err
+	}
+	clientRegistry.installer[key] = client
+	return client, nil
+}
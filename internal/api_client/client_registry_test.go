@@ -0,0 +1,106 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+	"github.com/vmware/terraform-provider-vcf/internal/credential_source"
+)
+
+func TestGetSddcManagerClient_shared(t *testing.T) {
+	server := fake_server.NewServer(t)
+
+	client, err := GetSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	other, err := GetSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if other != client {
+		t.Fatal("expected the client to be shared")
+	}
+
+	for _, c := range []*SddcManagerClient{client, other} {
+		if _, err = c.ApiClient.GetDomainsWithResponse(context.Background(), nil); err != nil {
+			t.Fatal("received an unexpected error", err)
+		}
+	}
+	if logins := server.RequestCount(http.MethodPost, "/v1/tokens"); logins != 1 {
+		t.Fatalf("expected a single login, got %d", logins)
+	}
+}
+
+func TestGetSddcManagerClient_differentSettings(t *testing.T) {
+	server := fake_server.NewServer(t)
+
+	client, err := GetSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	other, err := GetSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true,
+		WithRetry(0, 0))
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if other == client {
+		t.Fatal("expected clients with different settings not to be shared")
+	}
+	other, err = GetSddcManagerClient("other", fake_server.Password, server.Host(), "test", true)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if other == client {
+		t.Fatal("expected clients with different credentials not to be shared")
+	}
+}
+
+func TestGetInstallerClient_shared(t *testing.T) {
+	server := fake_server.NewServer(t)
+
+	client, err := GetInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	other, err := GetInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if other != client {
+		t.Fatal("expected the client to be shared")
+	}
+}
+
+func TestLoadCredentials_shared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte("[default]\nsddc_manager_api_key = key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := credential_source.Source{CredentialsFile: path}
+
+	credentials, err := LoadCredentials(context.Background(), source)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if credentials.SddcManagerApiKey != "key" {
+		t.Fatal("unexpected credentials", credentials)
+	}
+
+	// The credentials are not retrieved again
+	if err = os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	shared, err := LoadCredentials(context.Background(), source)
+	if err != nil || shared != credentials {
+		t.Fatal("expected the credentials to be shared", err)
+	}
+}
@@ -0,0 +1,222 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package fake_server
+
+import (
+	"net/http"
+
+	"github.com/vmware/vcf-sdk-go/installer"
+)
+
+// scriptedSddcTask is an SDDC deployment whose status advances every time it is retrieved.
+type scriptedSddcTask struct {
+	task     installer.SddcTask
+	statuses []string
+	polls    int
+}
+
+func (t *scriptedSddcTask) advance() {
+	if len(t.statuses) == 0 {
+		return
+	}
+	index := min(t.polls, len(t.statuses)-1)
+	t.polls++
+	t.task.Status = ptr(t.statuses[index])
+
+	t.task.SddcSubTasks = &[]installer.SddcSubTask{{
+		Name:        ptr("DeployVcenter"),
+		Description: ptr("Deploy vCenter Server"),
+		Status:      ptr(t.statuses[index]),
+	}}
+}
+
+// scriptedValidation is an SDDC spec validation whose checks advance through a list of result
+// statuses, one step every time the validation is retrieved.
+type scriptedValidation struct {
+	validation installer.Validation
+	statuses   []string
+	polls      int
+}
+
+func (v *scriptedValidation) advance() {
+	if len(v.statuses) == 0 {
+		return
+	}
+	index := min(v.polls, len(v.statuses)-1)
+	v.polls++
+
+	status := v.statuses[index]
+	v.validation.ResultStatus = &status
+	v.validation.ExecutionStatus = ptr("COMPLETED")
+	if status == ValidationInProgress {
+		v.validation.ExecutionStatus = ptr(ValidationInProgress)
+	}
+	check := installer.ValidationCheck{
+		Description:  ptr("Validate SDDC spec"),
+		ResultStatus: status,
+	}
+	if status == ValidationFailed {
+		check.ErrorResponse = &installer.Error{Message: ptr("SDDC spec validation failed")}
+	}
+	v.validation.ValidationChecks = &[]installer.ValidationCheck{check}
+}
+
+func (s *Server) registerInstallerHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/sddcs", s.getSddcTasks)
+	mux.HandleFunc("POST /v1/sddcs", s.deploySddc)
+	mux.HandleFunc("GET /v1/sddcs/{id}", s.getSddcTask)
+	mux.HandleFunc("PATCH /v1/sddcs/{id}", s.retrySddc)
+	mux.HandleFunc("POST /v1/sddcs/validations", s.validateSddcSpec)
+	mux.HandleFunc("GET /v1/sddcs/validations/{id}", s.getSddcSpecValidation)
+}
+
+// ScriptNextSddcTask sets the statuses the next SDDC deployment started on the server goes
+// through. By default a deployment completes successfully the first time it is retrieved.
+func (s *Server) ScriptNextSddcTask(statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sddcTaskScripts = append(s.sddcTaskScripts, statuses)
+}
+
+// ScriptNextSddcValidation sets the result statuses the next SDDC spec validation goes through.
+// By default a validation succeeds the first time it is retrieved.
+func (s *Server) ScriptNextSddcValidation(statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sddcValidationScripts = append(s.sddcValidationScripts, statuses)
+}
+
+// AddSddcTask seeds an SDDC deployment, e.g. a previously failed bring-up, and returns its ID.
+// The deployment goes through the given statuses every time it is retrieved.
+func (s *Server) AddSddcTask(task installer.SddcTask, statuses ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if task.Id == nil {
+		task.Id = ptr(s.newId("sddc"))
+	}
+	if task.Name == nil {
+		task.Name = ptr("Deploy SDDC")
+	}
+	s.sddcTasks[*task.Id] = &scriptedSddcTask{task: task, statuses: statuses}
+	s.sddcTaskOrder = append([]string{*task.Id}, s.sddcTaskOrder...)
+	return *task.Id
+}
+
+// SddcTask returns the current state of an SDDC deployment.
+func (s *Server) SddcTask(id string) (installer.SddcTask, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.sddcTasks[id]
+	if !ok {
+		return installer.SddcTask{}, false
+	}
+	return task.task, true
+}
+
+func (s *Server) getSddcTasks(w http.ResponseWriter, _ *http.Request) {
+	// The most recent deployment is listed first.
+	result := make([]installer.SddcTask, 0, len(s.sddcTaskOrder))
+	for _, id := range s.sddcTaskOrder {
+		result = append(result, s.sddcTasks[id].task)
+	}
+	writeJson(w, http.StatusOK, page(result))
+}
+
+func (s *Server) getSddcTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.sddcTasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "SDDC", r.PathValue("id"))
+		return
+	}
+	task.advance()
+	writeJson(w, http.StatusOK, task.task)
+}
+
+func (s *Server) deploySddc(w http.ResponseWriter, r *http.Request) {
+	var spec installer.SddcSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	task := &scriptedSddcTask{
+		task: installer.SddcTask{
+			Id:                ptr(s.newId("sddc")),
+			Name:              ptr("Deploy SDDC " + spec.SddcId),
+			Status:            ptr(SddcStatusInProgress),
+			CreationTimestamp: ptr("2025-01-01T00:00:00.000Z"),
+		},
+		statuses: s.nextSddcTaskScript(),
+	}
+	s.sddcTasks[*task.task.Id] = task
+	s.sddcTaskOrder = append([]string{*task.task.Id}, s.sddcTaskOrder...)
+
+	writeJson(w, http.StatusAccepted, task.task)
+}
+
+func (s *Server) retrySddc(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.sddcTasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "SDDC", r.PathValue("id"))
+		return
+	}
+	var spec installer.SddcSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	task.task.Status = ptr(SddcStatusInProgress)
+	task.statuses = s.nextSddcTaskScript()
+	task.polls = 0
+
+	writeJson(w, http.StatusAccepted, task.task)
+}
+
+func (s *Server) nextSddcTaskScript() []string {
+	if len(s.sddcTaskScripts) == 0 {
+		return []string{SddcStatusSuccessful}
+	}
+	script := s.sddcTaskScripts[0]
+	s.sddcTaskScripts = s.sddcTaskScripts[1:]
+	return script
+}
+
+func (s *Server) validateSddcSpec(w http.ResponseWriter, r *http.Request) {
+	var spec installer.SddcSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	statuses := []string{ValidationSucceeded}
+	if len(s.sddcValidationScripts) > 0 {
+		statuses = s.sddcValidationScripts[0]
+		s.sddcValidationScripts = s.sddcValidationScripts[1:]
+	}
+	validation := &scriptedValidation{
+		validation: installer.Validation{
+			Id:               ptr(s.newId("sddc-validation")),
+			ExecutionStatus:  ptr(ValidationInProgress),
+			ResultStatus:     ptr(ValidationInProgress),
+			ValidationChecks: &[]installer.ValidationCheck{},
+		},
+		statuses: statuses,
+	}
+	s.sddcValidations[*validation.validation.Id] = validation
+
+	writeJson(w, http.StatusAccepted, validation.validation)
+}
+
+func (s *Server) getSddcSpecValidation(w http.ResponseWriter, r *http.Request) {
+	validation, ok := s.sddcValidations[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Validation", r.PathValue("id"))
+		return
+	}
+	validation.advance()
+	writeJson(w, http.StatusOK, validation.validation)
+}
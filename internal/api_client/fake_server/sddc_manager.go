@@ -0,0 +1,743 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package fake_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+func (s *Server) registerSddcManagerHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/tokens", s.createToken)
+	mux.HandleFunc("PATCH /v1/tokens/access-token/refresh", s.refreshToken)
+
+	mux.HandleFunc("GET /v1/tasks", s.getTasks)
+	mux.HandleFunc("GET /v1/tasks/{id}", s.getTask)
+	mux.HandleFunc("DELETE /v1/tasks/{id}", s.cancelTask)
+
+	mux.HandleFunc("GET /v1/domains", s.getDomains)
+	mux.HandleFunc("POST /v1/domains", s.createDomain)
+	mux.HandleFunc("POST /v1/domains/validations", s.validate)
+	mux.HandleFunc("GET /v1/domains/{id}", s.getDomain)
+	mux.HandleFunc("PATCH /v1/domains/{id}", s.updateDomain)
+	mux.HandleFunc("POST /v1/domains/{id}/validations", s.validate)
+	mux.HandleFunc("DELETE /v1/domains/{id}", s.deleteDomain)
+
+	mux.HandleFunc("GET /v1/clusters", s.getClusters)
+	mux.HandleFunc("POST /v1/clusters", s.createCluster)
+	mux.HandleFunc("POST /v1/clusters/validations", s.validate)
+	mux.HandleFunc("GET /v1/clusters/{id}", s.getCluster)
+	mux.HandleFunc("PATCH /v1/clusters/{id}", s.updateCluster)
+	mux.HandleFunc("DELETE /v1/clusters/{id}", s.deleteCluster)
+	mux.HandleFunc("POST /v1/clusters/{id}/validations", s.validate)
+
+	mux.HandleFunc("GET /v1/hosts", s.getHosts)
+	mux.HandleFunc("POST /v1/hosts", s.commissionHosts)
+	mux.HandleFunc("DELETE /v1/hosts", s.decommissionHosts)
+	mux.HandleFunc("POST /v1/hosts/validations", s.validate)
+	mux.HandleFunc("POST /v1/hosts/validations/commissions", s.validate)
+	mux.HandleFunc("GET /v1/hosts/validations/{id}", s.getValidation)
+	mux.HandleFunc("GET /v1/hosts/{id}", s.getHost)
+
+	mux.HandleFunc("GET /v1/network-pools", s.getNetworkPools)
+	mux.HandleFunc("POST /v1/network-pools", s.createNetworkPool)
+	mux.HandleFunc("GET /v1/network-pools/{id}", s.getNetworkPool)
+	mux.HandleFunc("DELETE /v1/network-pools/{id}", s.deleteNetworkPool)
+
+	mux.HandleFunc("GET /v1/credentials", s.getCredentials)
+	mux.HandleFunc("PATCH /v1/credentials", s.updateCredentials)
+
+	mux.HandleFunc("GET /v1/personalities", s.getPersonalities)
+
+}
+
+// SetValidationChecks sets the checks returned by all SDDC Manager spec validations.
+// By default every validation succeeds without any checks.
+func (s *Server) SetValidationChecks(checks ...vcf.ValidationCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.validationChecks = checks
+}
+
+// SetValidationPolls sets how many times the SDDC Manager spec validations are retrieved while
+// they are still in progress. By default a validation has completed once it has been started.
+func (s *Server) SetValidationPolls(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.validationPolls = polls
+}
+
+// AddDomain seeds a workload domain and returns its ID.
+func (s *Server) AddDomain(domain vcf.Domain) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if domain.Id == nil {
+		domain.Id = ptr(s.newId("domain"))
+	}
+	s.domains[*domain.Id] = &domain
+	return *domain.Id
+}
+
+// Domain returns a workload domain known to the server.
+func (s *Server) Domain(id string) (vcf.Domain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain, ok := s.domains[id]
+	if !ok {
+		return vcf.Domain{}, false
+	}
+	return *domain, true
+}
+
+// AddCluster seeds a cluster, attaches it to the domain with the given ID, and returns its ID.
+func (s *Server) AddCluster(domainId string, cluster vcf.Cluster) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addCluster(domainId, cluster)
+}
+
+// Cluster returns a cluster known to the server.
+func (s *Server) Cluster(id string) (vcf.Cluster, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.clusters[id]
+	if !ok {
+		return vcf.Cluster{}, false
+	}
+	return *cluster, true
+}
+
+// AddHost seeds a host and returns its ID.
+func (s *Server) AddHost(host vcf.Host) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if host.Id == nil {
+		host.Id = ptr(s.newId("host"))
+	}
+	s.hosts[*host.Id] = &host
+	return *host.Id
+}
+
+// AddCredential seeds a credential and returns its ID.
+func (s *Server) AddCredential(credential vcf.Credential) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if credential.Id == nil {
+		credential.Id = ptr(s.newId("credential"))
+	}
+	s.credentials = append(s.credentials, credential)
+	return *credential.Id
+}
+
+// Credential returns the credential of an account of a resource known to the server.
+func (s *Server) Credential(resourceName, username string) (vcf.Credential, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, credential := range s.credentials {
+		if credential.Resource != nil && credential.Resource.ResourceName == resourceName &&
+			credential.Username != nil && *credential.Username == username {
+			return credential, true
+		}
+	}
+	return vcf.Credential{}, false
+}
+
+// AddPersonality seeds a cluster personality and returns its ID.
+func (s *Server) AddPersonality(personality vcf.Personality) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if personality.PersonalityId == nil {
+		personality.PersonalityId = ptr(s.newId("personality"))
+	}
+	s.personalities = append(s.personalities, personality)
+	return *personality.PersonalityId
+}
+
+// FailHostCommission makes the commissioning of the hosts with the given FQDNs fail. A task
+// which commissions such a host fails, while the other hosts of the task are commissioned.
+func (s *Server) FailHostCommission(fqdns ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, fqdn := range fqdns {
+		s.failingHosts[fqdn] = true
+	}
+}
+
+// HostById returns a host known to the server.
+func (s *Server) HostById(id string) (vcf.Host, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	host, ok := s.hosts[id]
+	if !ok {
+		return vcf.Host{}, false
+	}
+	return *host, true
+}
+
+// AddNetworkPool seeds a network pool and returns its ID.
+func (s *Server) AddNetworkPool(pool vcf.NetworkPool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pool.Id == nil {
+		pool.Id = ptr(s.newId("network-pool"))
+	}
+	s.networkPools[*pool.Id] = &pool
+	return *pool.Id
+}
+
+func (s *Server) addCluster(domainId string, cluster vcf.Cluster) string {
+	if cluster.Id == nil {
+		cluster.Id = ptr(s.newId("cluster"))
+	}
+	if cluster.IsDefault == nil {
+		cluster.IsDefault = ptr(false)
+	}
+	if cluster.IsStretched == nil {
+		cluster.IsStretched = ptr(false)
+	}
+	if cluster.PrimaryDatastoreName == nil {
+		cluster.PrimaryDatastoreName = ptr(*cluster.Id + "-ds")
+	}
+	if cluster.PrimaryDatastoreType == nil {
+		cluster.PrimaryDatastoreType = ptr("VSAN")
+	}
+	if cluster.Status == nil {
+		cluster.Status = ptr("ACTIVE")
+	}
+	if cluster.Domain == nil && domainId != "" {
+		cluster.Domain = &vcf.DomainReference{Id: domainId}
+	}
+	s.clusters[*cluster.Id] = &cluster
+
+	if domain, ok := s.domains[domainId]; ok {
+		var refs []vcf.ClusterReference
+		if domain.Clusters != nil {
+			refs = *domain.Clusters
+		}
+		refs = append(refs, vcf.ClusterReference{Id: *cluster.Id, Name: cluster.Name})
+		domain.Clusters = &refs
+	}
+	return *cluster.Id
+}
+
+// hostReferences assigns the hosts in the spec to a cluster and returns references to them.
+func (s *Server) hostReferences(hostSpecs []vcf.HostSpec, domainId, clusterId string) []vcf.HostReference {
+	refs := make([]vcf.HostReference, 0, len(hostSpecs))
+	for _, hostSpec := range hostSpecs {
+		ref := vcf.HostReference{Id: ptr(hostSpec.Id)}
+		if host, ok := s.hosts[hostSpec.Id]; ok {
+			ref.Fqdn = host.Fqdn
+			host.Status = ptr("ASSIGNED")
+			host.Domain = &vcf.DomainReference{Id: domainId}
+			host.Cluster = &vcf.ClusterReference{Id: clusterId}
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+func (s *Server) validate(w http.ResponseWriter, _ *http.Request) {
+	resultStatus := ValidationSucceeded
+	for _, check := range s.validationChecks {
+		if check.ResultStatus == ValidationFailed {
+			resultStatus = ValidationFailed
+		}
+	}
+	checks := append([]vcf.ValidationCheck{}, s.validationChecks...)
+	validation := &pendingValidation{
+		validation: vcf.Validation{
+			Id:               ptr(s.newId("validation")),
+			ExecutionStatus:  ptr("COMPLETED"),
+			ResultStatus:     &resultStatus,
+			ValidationChecks: &checks,
+		},
+		polls: s.validationPolls,
+	}
+	s.validations[*validation.validation.Id] = validation
+	writeJson(w, http.StatusOK, validation.get())
+}
+
+func (s *Server) getValidation(w http.ResponseWriter, r *http.Request) {
+	validation, ok := s.validations[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Validation", r.PathValue("id"))
+		return
+	}
+	writeJson(w, http.StatusOK, validation.get())
+	if validation.polls > 0 {
+		validation.polls--
+	}
+}
+
+// pendingValidation is an SDDC Manager spec validation which is in progress while it has polls
+// left, and has completed with its checks afterward.
+type pendingValidation struct {
+	validation vcf.Validation
+	polls      int
+}
+
+func (v *pendingValidation) get() vcf.Validation {
+	if v.polls == 0 {
+		return v.validation
+	}
+	return vcf.Validation{
+		Id:              v.validation.Id,
+		ExecutionStatus: ptr(ValidationInProgress),
+		ResultStatus:    ptr(ValidationInProgress),
+	}
+}
+
+func (s *Server) getDomains(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, page(sortedValues(s.domains)))
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	domain, ok := s.domains[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Domain", r.PathValue("id"))
+		return
+	}
+	writeJson(w, http.StatusOK, domain)
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request) {
+	var spec vcf.DomainCreationSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	domainId := s.newId("domain")
+	task := s.newTask("Creating domain", "DOMAIN_CREATION",
+		[]vcf.Resource{{ResourceId: domainId, Type: "Domain", Name: spec.DomainName}}, func() {
+			domain := &vcf.Domain{
+				Id:                    &domainId,
+				Name:                  spec.DomainName,
+				Status:                ptr("ACTIVE"),
+				Type:                  ptr("VI"),
+				SsoId:                 ptr(s.newId("sso")),
+				SsoName:               ptr("vsphere.local"),
+				IsManagementSsoDomain: ptr(false),
+				Clusters:              &[]vcf.ClusterReference{},
+				Vcenters: &[]vcf.VcenterReference{{
+					Id:   s.newId("vcenter"),
+					Fqdn: ptr(spec.VcenterSpec.NetworkDetailsSpec.DnsName),
+				}},
+				NsxtCluster: &vcf.NsxTClusterReference{Id: ptr(s.newId("nsxt-cluster"))},
+			}
+			if spec.SsoDomainSpec != nil && spec.SsoDomainSpec.SsoDomainName != nil {
+				domain.SsoName = spec.SsoDomainSpec.SsoDomainName
+			}
+			if spec.NsxTSpec != nil {
+				domain.NsxtCluster.Vip = spec.NsxTSpec.Vip
+				domain.NsxtCluster.VipFqdn = &spec.NsxTSpec.VipFqdn
+			}
+			s.domains[domainId] = domain
+			for _, clusterSpec := range spec.ComputeSpec.ClusterSpecs {
+				s.addClusterFromSpec(domainId, clusterSpec)
+			}
+		})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	domain, ok := s.domains[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Domain", r.PathValue("id"))
+		return
+	}
+	var spec vcf.DomainUpdateSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	if spec.MarkForDeletion != nil && *spec.MarkForDeletion {
+		domain.Status = ptr("MARKED_FOR_DELETION")
+	}
+
+	task := s.newTask("Updating domain", "DOMAIN_UPDATE",
+		[]vcf.Resource{{ResourceId: *domain.Id, Type: "Domain"}}, func() {
+			if spec.Name != nil {
+				domain.Name = spec.Name
+			}
+			if spec.ClusterSpec != nil {
+				s.addClusterFromSpec(*domain.Id, *spec.ClusterSpec)
+			}
+		})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request) {
+	domainId := r.PathValue("id")
+	domain, ok := s.domains[domainId]
+	if !ok {
+		writeNotFound(w, "Domain", domainId)
+		return
+	}
+
+	task := s.newTask("Deleting domain", "DOMAIN_DELETION",
+		[]vcf.Resource{{ResourceId: domainId, Type: "Domain"}}, func() {
+			if domain.Clusters != nil {
+				for _, ref := range *domain.Clusters {
+					delete(s.clusters, ref.Id)
+				}
+			}
+			delete(s.domains, domainId)
+		})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) addClusterFromSpec(domainId string, spec vcf.ClusterSpec) string {
+	clusterId := s.newId("cluster")
+	hosts := s.hostReferences(spec.HostSpecs, domainId, clusterId)
+	cluster := vcf.Cluster{
+		Id:    &clusterId,
+		Name:  spec.Name,
+		Hosts: &hosts,
+	}
+	if spec.DatastoreSpec.VsanDatastoreSpec != nil {
+		cluster.PrimaryDatastoreName = &spec.DatastoreSpec.VsanDatastoreSpec.DatastoreName
+		cluster.PrimaryDatastoreType = ptr("VSAN")
+	}
+	return s.addCluster(domainId, cluster)
+}
+
+func (s *Server) getClusters(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, page(sortedValues(s.clusters)))
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := s.clusters[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Cluster", r.PathValue("id"))
+		return
+	}
+	writeJson(w, http.StatusOK, cluster)
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request) {
+	var spec vcf.ClusterCreationSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+	if _, ok := s.domains[spec.DomainId]; !ok {
+		writeNotFound(w, "Domain", spec.DomainId)
+		return
+	}
+
+	var resources []vcf.Resource
+	var clusterIds []string
+	for _, clusterSpec := range spec.ComputeSpec.ClusterSpecs {
+		clusterId := s.newId("cluster")
+		clusterIds = append(clusterIds, clusterId)
+		resources = append(resources, vcf.Resource{ResourceId: clusterId, Type: "Cluster", Name: clusterSpec.Name})
+	}
+
+	task := s.newTask("Adding cluster", "CLUSTER_CREATION", resources, func() {
+		for i, clusterSpec := range spec.ComputeSpec.ClusterSpecs {
+			hosts := s.hostReferences(clusterSpec.HostSpecs, spec.DomainId, clusterIds[i])
+			cluster := vcf.Cluster{
+				Id:    &clusterIds[i],
+				Name:  clusterSpec.Name,
+				Hosts: &hosts,
+			}
+			if clusterSpec.DatastoreSpec.VsanDatastoreSpec != nil {
+				cluster.PrimaryDatastoreName = &clusterSpec.DatastoreSpec.VsanDatastoreSpec.DatastoreName
+			}
+			s.addCluster(spec.DomainId, cluster)
+		}
+	})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := s.clusters[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Cluster", r.PathValue("id"))
+		return
+	}
+	var spec vcf.ClusterUpdateSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	// Marking a cluster for deletion is synchronous.
+	if spec.MarkForDeletion != nil && *spec.MarkForDeletion {
+		cluster.Status = ptr("MARKED_FOR_DELETION")
+		writeJson(w, http.StatusOK, cluster)
+		return
+	}
+
+	task := s.newTask("Updating cluster", "CLUSTER_UPDATE",
+		[]vcf.Resource{{ResourceId: *cluster.Id, Type: "Cluster"}}, func() {
+			if spec.Name != nil {
+				cluster.Name = spec.Name
+			}
+			if spec.ClusterExpansionSpec != nil {
+				added := s.hostReferences(spec.ClusterExpansionSpec.HostSpecs, "", *cluster.Id)
+				hosts := append(*cluster.Hosts, added...)
+				cluster.Hosts = &hosts
+			}
+			if spec.ClusterCompactionSpec != nil {
+				hosts := slices.DeleteFunc(*cluster.Hosts, func(ref vcf.HostReference) bool {
+					for _, removed := range spec.ClusterCompactionSpec.Hosts {
+						if removed.Id != nil && ref.Id != nil && *removed.Id == *ref.Id {
+							return true
+						}
+					}
+					return false
+				})
+				cluster.Hosts = &hosts
+			}
+		})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, r *http.Request) {
+	clusterId := r.PathValue("id")
+	if _, ok := s.clusters[clusterId]; !ok {
+		writeNotFound(w, "Cluster", clusterId)
+		return
+	}
+
+	task := s.newTask("Removing cluster", "CLUSTER_DELETION",
+		[]vcf.Resource{{ResourceId: clusterId, Type: "Cluster"}}, func() {
+			delete(s.clusters, clusterId)
+			for _, domain := range s.domains {
+				if domain.Clusters == nil {
+					continue
+				}
+				refs := slices.DeleteFunc(*domain.Clusters, func(ref vcf.ClusterReference) bool {
+					return ref.Id == clusterId
+				})
+				domain.Clusters = &refs
+			}
+		})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) getHosts(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	var result []vcf.Host
+	for _, host := range sortedValues(s.hosts) {
+		if status != "" && (host.Status == nil || *host.Status != status) {
+			continue
+		}
+		result = append(result, host)
+	}
+	pageNumber, pageSize := s.pageParams(r, "page", "size")
+	writeJson(w, http.StatusOK, pageOf(result, pageNumber, pageSize))
+}
+
+func (s *Server) getHost(w http.ResponseWriter, r *http.Request) {
+	host, ok := s.hosts[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Host", r.PathValue("id"))
+		return
+	}
+	writeJson(w, http.StatusOK, host)
+}
+
+func (s *Server) commissionHosts(w http.ResponseWriter, r *http.Request) {
+	var specs []vcf.HostCommissionSpec
+	if !decode(w, r, &specs) {
+		return
+	}
+	for _, spec := range specs {
+		if _, ok := s.networkPools[spec.NetworkPoolId]; !ok {
+			writeNotFound(w, "Network pool", spec.NetworkPoolId)
+			return
+		}
+	}
+
+	var resources []vcf.Resource
+	var hostIds []string
+	for _, spec := range specs {
+		hostId := s.newId("host")
+		hostIds = append(hostIds, hostId)
+		resources = append(resources, vcf.Resource{ResourceId: hostId, Type: "Esxi", Fqdn: ptr(spec.Fqdn)})
+	}
+
+	commission := func() {
+		for i, spec := range specs {
+			if s.failingHosts[spec.Fqdn] {
+				continue
+			}
+			pool := s.networkPools[spec.NetworkPoolId]
+			s.hosts[hostIds[i]] = &vcf.Host{
+				Id:          &hostIds[i],
+				Fqdn:        ptr(spec.Fqdn),
+				Status:      ptr("UNASSIGNED_USEABLE"),
+				Networkpool: &vcf.NetworkPoolReference{Id: *pool.Id, Name: &pool.Name},
+			}
+			s.credentials = append(s.credentials, vcf.Credential{
+				Id:             ptr(s.newId("credential")),
+				AccountType:    ptr("USER"),
+				CredentialType: ptr("SSH"),
+				Username:       ptr(spec.Username),
+				Password:       ptr(spec.Password),
+				Resource: &vcf.AuthenticatedResource{
+					ResourceId:   hostIds[i],
+					ResourceName: spec.Fqdn,
+					ResourceType: "ESXI",
+				},
+			})
+		}
+	}
+
+	failing := slices.ContainsFunc(specs, func(spec vcf.HostCommissionSpec) bool { return s.failingHosts[spec.Fqdn] })
+	if failing {
+		s.taskScripts = append([][]string{{TaskStatusFailed}}, s.taskScripts...)
+	}
+	task := s.newTask("Commissioning host(s)", "HOST_COMMISSION", resources, commission)
+	if failing {
+		// The hosts which do not fail are commissioned nevertheless
+		s.tasks[*task.Id].onFailure = commission
+	}
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) decommissionHosts(w http.ResponseWriter, r *http.Request) {
+	var specs []vcf.HostDecommissionSpec
+	if !decode(w, r, &specs) {
+		return
+	}
+
+	task := s.newTask("Decommissioning host(s)", "HOST_DECOMMISSION", nil, func() {
+		for _, spec := range specs {
+			for id, host := range s.hosts {
+				if host.Fqdn != nil && *host.Fqdn == spec.Fqdn {
+					delete(s.hosts, id)
+				}
+			}
+			s.credentials = slices.DeleteFunc(s.credentials, func(credential vcf.Credential) bool {
+				return credential.Resource.ResourceName == spec.Fqdn
+			})
+		}
+	})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func (s *Server) getNetworkPools(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, page(sortedValues(s.networkPools)))
+}
+
+func (s *Server) getNetworkPool(w http.ResponseWriter, r *http.Request) {
+	pool, ok := s.networkPools[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Network pool", r.PathValue("id"))
+		return
+	}
+	writeJson(w, http.StatusOK, pool)
+}
+
+func (s *Server) createNetworkPool(w http.ResponseWriter, r *http.Request) {
+	var pool vcf.NetworkPool
+	if !decode(w, r, &pool) {
+		return
+	}
+	pool.Id = ptr(s.newId("network-pool"))
+	s.networkPools[*pool.Id] = &pool
+	writeJson(w, http.StatusCreated, pool)
+}
+
+func (s *Server) deleteNetworkPool(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.networkPools[r.PathValue("id")]; !ok {
+		writeNotFound(w, "Network pool", r.PathValue("id"))
+		return
+	}
+	delete(s.networkPools, r.PathValue("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getCredentials(w http.ResponseWriter, r *http.Request) {
+	resourceName := r.URL.Query().Get("resourceName")
+	var result []vcf.Credential
+	for _, credential := range s.credentials {
+		if resourceName != "" && credential.Resource.ResourceName != resourceName {
+			continue
+		}
+		result = append(result, credential)
+	}
+	pageNumber, pageSize := s.pageParams(r, "pageNumber", "pageSize")
+	writeJson(w, http.StatusOK, pageOf(result, pageNumber, pageSize))
+}
+
+func (s *Server) getPersonalities(w http.ResponseWriter, r *http.Request) {
+	personalityName := r.URL.Query().Get("personalityName")
+	var result []vcf.Personality
+	for _, personality := range s.personalities {
+		if personalityName != "" && (personality.PersonalityName == nil || *personality.PersonalityName != personalityName) {
+			continue
+		}
+		result = append(result, personality)
+	}
+	// Unlike the other lists, the pages of personalities are numbered from 1
+	pageNumber, pageSize := s.pageParams(r, "page", "size")
+	writeJson(w, http.StatusOK, pageFrom(result, 1, max(pageNumber, 1), pageSize))
+}
+
+func (s *Server) updateCredentials(w http.ResponseWriter, r *http.Request) {
+	var spec vcf.CredentialsUpdateSpec
+	if !decode(w, r, &spec) {
+		return
+	}
+	task := s.newTask("Updating credentials", "CREDENTIALS_UPDATE", nil, func() {
+		if spec.OperationType != "UPDATE" {
+			return
+		}
+		for _, element := range spec.Elements {
+			for _, update := range element.Credentials {
+				for i, credential := range s.credentials {
+					if credential.Resource != nil && element.ResourceName != nil &&
+						credential.Resource.ResourceName == *element.ResourceName &&
+						credential.Username != nil && *credential.Username == update.Username {
+						s.credentials[i].Password = update.Password
+					}
+				}
+			}
+		}
+	})
+	writeJson(w, http.StatusAccepted, task)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, dest interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dest); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return false
+	}
+	return true
+}
+
+// sortedValues returns the values of a map ordered by key so list responses are stable.
+func sortedValues[T any](m map[string]*T) []T {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]T, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *m[key])
+	}
+	return result
+}
@@ -0,0 +1,462 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fake_server provides an in-process fake of the SDDC Manager and VCF Installer APIs.
+// It is meant to be used by unit tests which exercise the API clients, the task tracker and
+// the resource CRUD functions without access to a real VCF instance.
+package fake_server
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/installer"
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+const (
+	// Username is the only user the fake server issues tokens for.
+	Username = "administrator@vsphere.local"
+	// Password is the password of Username.
+	Password = "VMware1!VMware1!"
+	// ApiKey is the API key of the service account the fake server issues tokens for.
+	ApiKey = "c2VydmljZS1hY2NvdW50LWFwaS1rZXk"
+
+	// Task status values used by SDDC Manager.
+	TaskStatusInProgress = "IN_PROGRESS"
+	TaskStatusSuccessful = "Successful"
+	TaskStatusFailed     = "Failed"
+	TaskStatusCancelled  = "Cancelled"
+
+	// Status values of an SDDC deployment (bring-up) task on the installer.
+	SddcStatusInProgress = "IN_PROGRESS"
+	SddcStatusSuccessful = "COMPLETED_WITH_SUCCESS"
+	SddcStatusFailed     = "COMPLETED_WITH_FAILURE"
+
+	// Validation check result values.
+	ValidationSucceeded  = "SUCCEEDED"
+	ValidationFailed     = "FAILED"
+	ValidationInProgress = "IN_PROGRESS"
+)
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+type failure struct {
+	method         string
+	path           string
+	remaining      int
+	statusCode     int
+	body           interface{}
+	header         http.Header
+	dropConnection bool
+}
+
+// Server is a fake SDDC Manager and VCF Installer backed by an httptest TLS server.
+//
+// The same instance serves the endpoints of both products, so it can be plugged into
+// api_client.NewSddcManagerClient as well as api_client.NewInstallerClient. All state is kept
+// in memory and can be seeded and inspected by tests through the exported methods.
+type Server struct {
+	server *httptest.Server
+
+	mu                  sync.Mutex
+	lastId              int
+	accessTokens        map[string]bool
+	refreshTokens       map[string]bool
+	accessTokenLifetime time.Duration
+	requests            []Request
+	failures            []*failure
+
+	tasks       map[string]*scriptedTask
+	taskScripts [][]string
+	taskOrder   []string
+
+	domains       map[string]*vcf.Domain
+	clusters      map[string]*vcf.Cluster
+	hosts         map[string]*vcf.Host
+	failingHosts  map[string]bool
+	networkPools  map[string]*vcf.NetworkPool
+	credentials   []vcf.Credential
+	personalities []vcf.Personality
+
+	validationChecks []vcf.ValidationCheck
+	validationPolls  int
+	validations      map[string]*pendingValidation
+	pageSize         int
+
+	sddcTasks             map[string]*scriptedSddcTask
+	sddcTaskOrder         []string
+	sddcTaskScripts       [][]string
+	sddcValidations       map[string]*scriptedValidation
+	sddcValidationScripts [][]string
+}
+
+// NewServer starts a new fake server which is shut down when the test completes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		accessTokens:        make(map[string]bool),
+		refreshTokens:       make(map[string]bool),
+		accessTokenLifetime: time.Hour,
+		tasks:               make(map[string]*scriptedTask),
+		domains:             make(map[string]*vcf.Domain),
+		clusters:            make(map[string]*vcf.Cluster),
+		hosts:               make(map[string]*vcf.Host),
+		failingHosts:        make(map[string]bool),
+		networkPools:        make(map[string]*vcf.NetworkPool),
+		validations:         make(map[string]*pendingValidation),
+		sddcTasks:           make(map[string]*scriptedSddcTask),
+		sddcValidations:     make(map[string]*scriptedValidation),
+	}
+
+	mux := http.NewServeMux()
+	s.registerSddcManagerHandlers(mux)
+	s.registerInstallerHandlers(mux)
+
+	s.server = httptest.NewTLSServer(s.handler(mux))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// Host returns the "host:port" address of the server, in the form expected by the API clients.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.server.URL, "https://")
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Certificate returns the self-signed certificate presented by the server.
+func (s *Server) Certificate() *x509.Certificate {
+	return s.server.Certificate()
+}
+
+// Requests returns all requests received by the server so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns the number of requests received for the given method and path.
+func (s *Server) RequestCount(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, req := range s.requests {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+// FailRequests makes the next "times" requests matching method and path respond with
+// statusCode and the provided error in the response body.
+func (s *Server) FailRequests(method, path string, times, statusCode int, err vcf.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure{
+		method:     method,
+		path:       path,
+		remaining:  times,
+		statusCode: statusCode,
+		body:       err,
+	})
+}
+
+// ThrottleRequests makes the next "times" requests matching method and path respond with
+// 429 Too Many Requests and the given value of the Retry-After header.
+func (s *Server) ThrottleRequests(method, path string, times int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := "Too many requests"
+	s.failures = append(s.failures, &failure{
+		method:     method,
+		path:       path,
+		remaining:  times,
+		statusCode: http.StatusTooManyRequests,
+		body:       vcf.Error{Message: &message},
+		header:     http.Header{"Retry-After": []string{retryAfter}},
+	})
+}
+
+// ResetConnections makes the next "times" requests matching method and path fail by closing
+// the connection without sending a response.
+func (s *Server) ResetConnections(method, path string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure{
+		method:         method,
+		path:           path,
+		remaining:      times,
+		dropConnection: true,
+	})
+}
+
+// SetAccessTokenLifetime sets the lifetime of the access tokens issued from now on, which is
+// announced through the "exp" claim of the token. The default lifetime is one hour.
+func (s *Server) SetAccessTokenLifetime(lifetime time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessTokenLifetime = lifetime
+}
+
+// ExpireRefreshTokens invalidates all refresh tokens issued so far, so that a new access token
+// can only be obtained by logging in again.
+func (s *Server) ExpireRefreshTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshTokens = make(map[string]bool)
+}
+
+// ExpireAccessTokens invalidates all access tokens issued so far, any request which uses one
+// of them is answered with 401 Unauthorized.
+func (s *Server) ExpireAccessTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessTokens = make(map[string]bool)
+}
+
+// IssueAccessToken returns a new access token, as if it had been issued to a pipeline
+// beforehand, with the lifetime set with SetAccessTokenLifetime.
+func (s *Server) IssueAccessToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.newAccessToken()
+}
+
+func (s *Server) handler(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Body:   body,
+		})
+		injected := s.nextFailure(r.Method, r.URL.Path)
+		authorized := strings.HasPrefix(r.URL.Path, "/v1/tokens") ||
+			s.accessTokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		s.mu.Unlock()
+
+		if injected != nil {
+			injected.write(w)
+			return
+		}
+		if !authorized {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid or expired access token")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (f *failure) write(w http.ResponseWriter) {
+	if f.dropConnection {
+		if conn, _, err := http.NewResponseController(w).Hijack(); err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+	for key, values := range f.header {
+		w.Header()[key] = values
+	}
+	writeJson(w, f.statusCode, f.body)
+}
+
+func (s *Server) nextFailure(method, path string) *failure {
+	for i, f := range s.failures {
+		if f.method == method && f.path == path {
+			f.remaining--
+			if f.remaining <= 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+			return f
+		}
+	}
+	return nil
+}
+
+// newId returns a new unique identifier. Must be called with the lock held.
+func (s *Server) newId(kind string) string {
+	s.lastId++
+	return fmt.Sprintf("%s-%04d", kind, s.lastId)
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	var spec vcf.TokenCreationSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+	if spec.ApiKey != nil {
+		if *spec.ApiKey != ApiKey {
+			writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid API key")
+			return
+		}
+	} else if spec.Username == nil || spec.Password == nil || *spec.Username != Username || *spec.Password != Password {
+		writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid username or password")
+		return
+	}
+
+	writeJson(w, http.StatusOK, s.newTokenPair())
+}
+
+func (s *Server) refreshToken(w http.ResponseWriter, r *http.Request) {
+	var refreshTokenId string
+	if err := json.NewDecoder(r.Body).Decode(&refreshTokenId); err != nil || !s.refreshTokens[refreshTokenId] {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Invalid refresh token")
+		return
+	}
+
+	writeJson(w, http.StatusOK, s.newAccessToken())
+}
+
+func (s *Server) newTokenPair() vcf.TokenPair {
+	refreshToken := s.newId("refresh-token")
+	s.refreshTokens[refreshToken] = true
+
+	return vcf.TokenPair{
+		AccessToken:  ptr(s.newAccessToken()),
+		RefreshToken: &vcf.RefreshToken{Id: &refreshToken},
+	}
+}
+
+// newAccessToken issues an unsigned JWT, which carries the subject and the expiry of the token
+// the same way the tokens issued by SDDC Manager do.
+func (s *Server) newAccessToken() string {
+	claims, _ := json.Marshal(map[string]interface{}{
+		"sub": Username,
+		"jti": s.newId("access-token"),
+		"exp": time.Now().Add(s.accessTokenLifetime).Unix(),
+	})
+	accessToken := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)),
+		base64.RawURLEncoding.EncodeToString(claims),
+		"",
+	}, ".")
+	s.accessTokens[accessToken] = true
+
+	return accessToken
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errorCode, message string) {
+	writeJson(w, statusCode, vcf.Error{
+		ErrorCode: &errorCode,
+		Message:   &message,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s with ID %s not found", kind, id))
+}
+
+func page[T any](elements []T) map[string]interface{} {
+	return pageOf(elements, 0, 0)
+}
+
+// pageOf returns the page with the given number of a list whose pages are numbered from 0. A
+// page size of 0 returns the whole list in a single page.
+func pageOf[T any](elements []T, pageNumber, pageSize int) map[string]interface{} {
+	return pageFrom(elements, 0, pageNumber, pageSize)
+}
+
+// pageFrom returns the page with the given number of a list whose pages are numbered from
+// firstPage, like pageOf.
+func pageFrom[T any](elements []T, firstPage, pageNumber, pageSize int) map[string]interface{} {
+	if elements == nil {
+		elements = []T{}
+	}
+	total := len(elements)
+	totalPages := 1
+	if pageSize > 0 {
+		totalPages = max((total+pageSize-1)/pageSize, 1)
+		start := min(max(pageNumber-firstPage, 0)*pageSize, total)
+		elements = elements[start:min(start+pageSize, total)]
+	} else {
+		pageNumber = firstPage
+	}
+	return map[string]interface{}{
+		"elements": elements,
+		"pageMetadata": vcf.PageMetadata{
+			PageNumber:    ptr(int32(pageNumber)),
+			PageSize:      ptr(int32(len(elements))),
+			TotalElements: ptr(int32(total)),
+			TotalPages:    ptr(int32(totalPages)),
+		},
+	}
+}
+
+// pageParams returns the page number and size requested from an endpoint which supports paging,
+// read from the given query parameters. The page size defaults to the one set with SetPageSize.
+func (s *Server) pageParams(r *http.Request, pageNumberParam, pageSizeParam string) (int, int) {
+	pageNumber, _ := strconv.Atoi(r.URL.Query().Get(pageNumberParam))
+	pageSize, err := strconv.Atoi(r.URL.Query().Get(pageSizeParam))
+	if err != nil {
+		pageSize = s.pageSize
+	}
+	return pageNumber, pageSize
+}
+
+// SetPageSize makes the endpoints which support paging, e.g. GET /v1/hosts and GET
+// /v1/credentials, return lists in pages of the given size unless the request sets a page size.
+func (s *Server) SetPageSize(pageSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pageSize = pageSize
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// convertError converts an SDDC Manager error into its installer counterpart.
+func convertError(err vcf.Error) installer.Error {
+	var result installer.Error
+	data, _ := json.Marshal(err)
+	_ = json.Unmarshal(data, &result)
+	return result
+}
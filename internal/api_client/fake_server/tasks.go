@@ -0,0 +1,165 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package fake_server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+// defaultTaskScript is used for tasks created by the server when no script has been queued
+// with ScriptNextTask. Such tasks succeed the first time they are polled.
+var defaultTaskScript = []string{TaskStatusSuccessful}
+
+// scriptedTask is a task whose status advances through a list of statuses,
+// one step every time it is retrieved.
+type scriptedTask struct {
+	task      vcf.Task
+	statuses  []string
+	polls     int
+	onSuccess func()
+	onFailure func()
+	finished  bool
+}
+
+func (t *scriptedTask) advance() {
+	if t.finished || len(t.statuses) == 0 {
+		return
+	}
+	index := t.polls
+	if index >= len(t.statuses) {
+		index = len(t.statuses) - 1
+	}
+	t.polls++
+	t.task.Status = ptr(t.statuses[index])
+
+	switch *t.task.Status {
+	case TaskStatusInProgress, "In Progress", "Pending":
+		return
+	case TaskStatusFailed, TaskStatusCancelled:
+		t.finished = true
+		if t.task.Errors == nil {
+			t.task.Errors = &[]vcf.Error{{Message: ptr("Task " + *t.task.Name + " failed")}}
+		}
+		if t.onFailure != nil {
+			t.onFailure()
+		}
+	default:
+		t.finished = true
+		if t.onSuccess != nil {
+			t.onSuccess()
+		}
+	}
+}
+
+// ScriptNextTask sets the statuses the next task created by the server goes through.
+// Calls are queued, so tests can script several tasks in advance.
+func (s *Server) ScriptNextTask(statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.taskScripts = append(s.taskScripts, statuses)
+}
+
+// AddTask registers a task that goes through the given statuses and returns its ID.
+// The task status advances every time the task is retrieved and stays at the last status.
+func (s *Server) AddTask(task vcf.Task, statuses ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if task.Id == nil {
+		task.Id = ptr(s.newId("task"))
+	}
+	if task.Name == nil {
+		task.Name = ptr("Task " + *task.Id)
+	}
+	if task.Type == nil {
+		task.Type = ptr("FAKE")
+	}
+	if task.Status == nil {
+		task.Status = ptr(TaskStatusInProgress)
+	}
+	s.tasks[*task.Id] = &scriptedTask{task: task, statuses: statuses}
+	s.taskOrder = append(s.taskOrder, *task.Id)
+
+	return *task.Id
+}
+
+// Task returns the current state of a task.
+func (s *Server) Task(id string) (vcf.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[id]
+	if !ok {
+		return vcf.Task{}, false
+	}
+	return task.task, true
+}
+
+// newTask creates a task for an operation. onSuccess is invoked with the lock held once the
+// task transitions into a successful state. Must be called with the lock held.
+func (s *Server) newTask(name, taskType string, resources []vcf.Resource, onSuccess func()) vcf.Task {
+	statuses := defaultTaskScript
+	if len(s.taskScripts) > 0 {
+		statuses = s.taskScripts[0]
+		s.taskScripts = s.taskScripts[1:]
+	}
+
+	task := vcf.Task{
+		Id:     ptr(s.newId("task")),
+		Name:   &name,
+		Type:   &taskType,
+		Status: ptr(TaskStatusInProgress),
+	}
+	if len(resources) > 0 {
+		task.Resources = &resources
+	}
+	s.tasks[*task.Id] = &scriptedTask{task: task, statuses: statuses, onSuccess: onSuccess}
+	s.taskOrder = append(s.taskOrder, *task.Id)
+
+	return task
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.tasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Task", r.PathValue("id"))
+		return
+	}
+	task.advance()
+	writeJson(w, http.StatusOK, task.task)
+}
+
+func (s *Server) cancelTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.tasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Task", r.PathValue("id"))
+		return
+	}
+	if task.finished {
+		writeError(w, http.StatusConflict, "TASK_COMPLETED", "Task "+*task.task.Id+" has already completed")
+		return
+	}
+	task.finished = true
+	task.task.Status = ptr(TaskStatusCancelled)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getTasks(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("taskStatus")
+	var result []vcf.Task
+	for _, id := range s.taskOrder {
+		task := s.tasks[id].task
+		if status != "" && !strings.EqualFold(status, *task.Status) {
+			continue
+		}
+		result = append(result, task)
+	}
+	pageNumber, pageSize := s.pageParams(r, "pageNumber", "pageSize")
+	writeJson(w, http.StatusOK, pageOf(result, pageNumber, pageSize))
+}
@@ -6,13 +6,10 @@ package api_client
 
 import (
 	"context"
-	"crypto/tls"
-	"errors"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/vcf-sdk-go/installer"
 )
 
@@ -21,79 +18,111 @@ type InstallerClient struct {
 	username           string
 	password           string
 	vcfInstallerUrl    string
-	accessToken        *string
 	ApiClient          *installer.ClientWithResponses
 	allowUnverifiedTls bool
-	lastRefreshTime    time.Time
-	isRefreshing       bool
+	config             clientConfig
+	tokens             *tokenManager
 }
 
 // NewInstallerClient constructs new Client instance with vcf credentials.
-func NewInstallerClient(username, password, url string, allowUnverifiedTls bool) *InstallerClient {
+func NewInstallerClient(username, password, url string, allowUnverifiedTls bool, opts ...ClientOption) *InstallerClient {
 	return &InstallerClient{
 		username:           username,
 		password:           password,
 		vcfInstallerUrl:    url,
 		allowUnverifiedTls: allowUnverifiedTls,
-		lastRefreshTime:    time.Now(),
-		isRefreshing:       false,
+		config:             newClientConfig(opts),
 	}
 }
 
-func (installerClient *InstallerClient) authEditor(ctx context.Context, req *http.Request) error {
-	// Refresh the access token every 20 minutes so that SDK operations won't start to
-	// fail with 401, 403 because of token expiration, during long-running tasks
-	if time.Since(installerClient.lastRefreshTime) > 20*time.Minute &&
-		!installerClient.isRefreshing {
-		err := installerClient.Connect()
-		if err != nil {
-			return err
-		}
-	}
-
-	if installerClient.accessToken != nil {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *installerClient.accessToken))
-	}
-
+func (installerClient *InstallerClient) requestEditor(ctx context.Context, req *http.Request) error {
 	req.Header.Add("Content-Type", "application/json")
 
 	return nil
 }
 
-func (installerClient *InstallerClient) Connect() error {
-	installerClient.isRefreshing = true
+// Init creates the API client without logging in to the installer. The client logs in on its
+// first request and renews the access token transparently afterward, so an unreachable
+// installer is only reported by the requests sent to it.
+func (installerClient *InstallerClient) Init() error {
+	if installerClient.ApiClient != nil {
+		return nil
+	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: installerClient.allowUnverifiedTls},
+	tokens := &tokenManager{}
+	httpClient, err := installerClient.config.newHttpClient(installerClient.allowUnverifiedTls, tokens)
+	if err != nil {
+		return err
 	}
-	httpClient := &http.Client{Transport: tr}
 	client, err := installer.NewClientWithResponses(fmt.Sprintf("https://%s", installerClient.vcfInstallerUrl),
-		installer.WithRequestEditorFn(installerClient.authEditor), installer.WithHTTPClient(httpClient))
+		installer.WithRequestEditorFn(installerClient.requestEditor), installer.WithHTTPClient(httpClient))
 	if err != nil {
 		return err
 	}
 
+	tokens.login = func(ctx context.Context) (tokenPair, error) {
+		return installerClient.createToken(ctx, client)
+	}
+	tokens.refresh = func(ctx context.Context, refreshToken string) (string, error) {
+		return installerClient.refreshAccessToken(ctx, client, refreshToken)
+	}
+
 	installerClient.ApiClient = client
+	installerClient.tokens = tokens
+	return nil
+}
+
+// Connect creates the API client, if Init has not been called yet, and logs in to the installer right
+// away, which reports wrong credentials or an unreachable installer immediately.
+func (installerClient *InstallerClient) Connect() error {
+	if err := installerClient.Init(); err != nil {
+		return err
+	}
+
+	_, err := installerClient.tokens.AccessToken(context.Background())
+	return err
+}
 
+func (installerClient *InstallerClient) createToken(ctx context.Context, client *installer.ClientWithResponses) (tokenPair, error) {
 	tokenCreationSpec := installer.TokenCreationSpec{
 		Username: &installerClient.username,
 		Password: &installerClient.password,
 	}
 
-	res, err := client.CreateTokenWithResponse(context.Background(), tokenCreationSpec)
+	res, err := client.CreateTokenWithResponse(ctx, tokenCreationSpec)
 	if err != nil {
-		return err
+		return tokenPair{}, err
 	}
 
-	tokenPair, vcfErr := GetResponseAs[installer.TokenPair](res)
-	if vcfErr != nil {
-		return errors.New(*vcfErr.Message)
+	tokens, vcfErr := GetResponseAs[installer.TokenPair](res)
+	if vcfErr != nil && vcfErr.Message != nil {
+		return tokenPair{}, NewApiError(vcfErr)
+	}
+	if tokens == nil || tokens.AccessToken == nil {
+		return tokenPair{}, fmt.Errorf("failed to create an access token: %s", res.Status())
 	}
-	installerClient.accessToken = tokenPair.AccessToken
-	installerClient.lastRefreshTime = time.Now()
-	installerClient.isRefreshing = false
 
-	return nil
+	result := tokenPair{accessToken: *tokens.AccessToken}
+	if tokens.RefreshToken != nil && tokens.RefreshToken.Id != nil {
+		result.refreshToken = *tokens.RefreshToken.Id
+	}
+	return result, nil
+}
+
+func (installerClient *InstallerClient) refreshAccessToken(ctx context.Context, client *installer.ClientWithResponses, refreshToken string) (string, error) {
+	res, err := client.RefreshAccessTokenWithResponse(ctx, refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	accessToken, vcfErr := GetResponseAs[string](res)
+	if vcfErr != nil && vcfErr.Message != nil {
+		return "", NewApiError(vcfErr)
+	}
+	if accessToken == nil || *accessToken == "" {
+		return "", fmt.Errorf("failed to refresh the access token: %s", res.Status())
+	}
+	return *accessToken, nil
 }
 
 func (installerClient *InstallerClient) GetResourceIdAssociatedWithTask(ctx context.Context, taskId, resourceType string) (string, error) {
@@ -101,7 +130,7 @@ func (installerClient *InstallerClient) GetResourceIdAssociatedWithTask(ctx cont
 	if err != nil {
 		return "", err
 	}
-	if len(*task.Resources) == 0 {
+	if task.Resources == nil || len(*task.Resources) == 0 {
 		return "", fmt.Errorf("no resources associated with Task with ID %q", taskId)
 	}
 	for _, resource := range *task.Resources {
@@ -115,11 +144,18 @@ func (installerClient *InstallerClient) GetResourceIdAssociatedWithTask(ctx cont
 func (installerClient *InstallerClient) getTask(ctx context.Context, taskId string) (*installer.Task, error) {
 	apiClient := installerClient.ApiClient
 	res, err := apiClient.GetTaskWithResponse(ctx, taskId)
-	task, vcfErr := GetResponseAs[installer.Task](res)
-	if err != nil || vcfErr != nil {
-		log.Println("error = ", err)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("failed to get Task with ID %q: %s", taskId, err))
 		return nil, err
 	}
+	task, vcfErr := GetResponseAs[installer.Task](res)
+	if vcfErr != nil {
+		LogError(vcfErr, ctx)
+		return nil, NewApiError(vcfErr)
+	}
+	if task == nil {
+		return nil, fmt.Errorf("failed to get Task with ID %q: %s", taskId, res.Status())
+	}
 
 	return task, nil
 }
@@ -0,0 +1,185 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vcf-sdk-go/installer"
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+const (
+	// SDDC deployment and validation status constants.
+	sddcStatusNotStarted   = "NOT_STARTED"
+	validationStatusFailed = "FAILED"
+	validationUnknown      = "UNKNOWN"
+)
+
+// NewSddcTaskTracker creates a tracker for an SDDC deployment (bring-up) of this installer.
+func (installerClient *InstallerClient) NewSddcTaskTracker(ctx context.Context, sddcId string) *TaskTracker {
+	tracker := NewTaskTrackerForSource(ctx, &sddcTaskSource{client: installerClient.ApiClient}, sddcId)
+	tracker.pollingInterval = installerClient.config.getTaskPollingInterval(defaultPollingInterval)
+	return tracker
+}
+
+// NewValidationTracker creates a tracker for an SDDC spec validation of this installer. The
+// tracker waits for all validation checks to finish, the result of the validation has to be
+// retrieved afterward.
+func (installerClient *InstallerClient) NewValidationTracker(ctx context.Context, validationId string) *TaskTracker {
+	tracker := NewTaskTrackerForSource(ctx, &validationTaskSource{client: installerClient.ApiClient}, validationId)
+	tracker.pollingInterval = installerClient.config.getTaskPollingInterval(defaultValidationPollingInterval)
+	return tracker
+}
+
+// sddcTaskSource retrieves SDDC deployments from the installer.
+type sddcTaskSource struct {
+	client *installer.ClientWithResponses
+}
+
+func (s *sddcTaskSource) GetTask(ctx context.Context, taskId string) (*TrackedTask, error) {
+	res, err := s.client.GetSddcTaskByIDWithResponse(ctx, taskId)
+	if err != nil {
+		return nil, &TaskPollError{Err: err, Transient: true}
+	}
+	task, err := getTaskResponse[installer.SddcTask](ctx, res, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &TrackedTask{
+		Id:     stringValue(task.Id),
+		Name:   stringValue(task.Name),
+		Status: stringValue(task.Status),
+		State:  sddcTaskState(stringValue(task.Status)),
+	}
+	if task.SddcSubTasks == nil {
+		return tracked, nil
+	}
+	tracked.Subtasks = make([]TrackedSubtask, 0, len(*task.SddcSubTasks))
+	for _, subtask := range *task.SddcSubTasks {
+		description := stringValue(subtask.Description)
+		if description == "" {
+			description = stringValue(subtask.Name)
+		}
+		trackedSubtask := TrackedSubtask{
+			Description: description,
+			Status:      stringValue(subtask.Status),
+			State:       sddcTaskState(stringValue(subtask.Status)),
+		}
+		if subtask.Errors != nil {
+			trackedSubtask.Errors = mapValues(*subtask.Errors, ConvertToVcfError)
+		}
+		tracked.Subtasks = append(tracked.Subtasks, trackedSubtask)
+	}
+	return tracked, nil
+}
+
+func (s *sddcTaskSource) DescribeRunningTask(taskId string) string {
+	return fmt.Sprintf("The deployment is still running on the VCF Installer, track its progress in the VCF Installer UI "+
+		"or with GET /v1/sddcs/%s", taskId)
+}
+
+// sddcTaskState maps the status of an SDDC deployment or one of its subtasks, e.g.
+// COMPLETED_WITH_SUCCESS or COMPLETED_WITH_FAILURE.
+func sddcTaskState(status string) TaskState {
+	switch {
+	case statusIn(status, statusInProgressUppercase):
+		return TaskStateRunning
+	case status == "" || statusIn(status, sddcStatusNotStarted, statusPending):
+		return TaskStatePending
+	case strings.Contains(strings.ToUpper(status), "FAIL"):
+		return TaskStateFailed
+	default:
+		return TaskStateSucceeded
+	}
+}
+
+// validationTaskSource retrieves SDDC spec validations from the installer. Every validation
+// check is reported as a subtask.
+type validationTaskSource struct {
+	client *installer.ClientWithResponses
+}
+
+func (s *validationTaskSource) GetTask(ctx context.Context, taskId string) (*TrackedTask, error) {
+	res, err := s.client.GetSddcSpecValidationWithResponse(ctx, taskId)
+	if err != nil {
+		return nil, &TaskPollError{Err: err, Transient: true}
+	}
+	validation, err := getTaskResponse[installer.Validation](ctx, res, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return trackValidation(ConvertToVcfValidation(*validation), "SDDC spec validation"), nil
+}
+
+func (s *validationTaskSource) DescribeRunningTask(taskId string) string {
+	return fmt.Sprintf("The validation is still running on the VCF Installer, track its progress with "+
+		"GET /v1/sddcs/validations/%s", taskId)
+}
+
+// trackValidation maps a validation of the installer or of SDDC Manager, every validation check
+// is reported as a subtask. The name is used if the validation has no description.
+func trackValidation(validation vcf.Validation, name string) *TrackedTask {
+	tracked := &TrackedTask{
+		Id:     stringValue(validation.Id),
+		Name:   stringValue(validation.Description),
+		Status: stringValue(validation.ExecutionStatus),
+		State:  validationTaskState(validation),
+	}
+	if tracked.Name == "" {
+		tracked.Name = name
+	}
+	if validation.ValidationChecks == nil {
+		return tracked
+	}
+	tracked.Subtasks = make([]TrackedSubtask, 0, len(*validation.ValidationChecks))
+	for _, check := range *validation.ValidationChecks {
+		trackedSubtask := TrackedSubtask{
+			Description: stringValue(check.Description),
+			Status:      check.ResultStatus,
+			State:       validationCheckState(check.ResultStatus),
+		}
+		if check.ErrorResponse != nil {
+			trackedSubtask.Errors = []vcf.Error{*check.ErrorResponse}
+		}
+		tracked.Subtasks = append(tracked.Subtasks, trackedSubtask)
+	}
+	return tracked
+}
+
+// validationTaskState maps the execution status of a validation. A validation whose checks
+// have failed has still run successfully, the failed checks are reported by the caller.
+func validationTaskState(validation vcf.Validation) TaskState {
+	if statusIn(stringValue(validation.ExecutionStatus), statusInProgressUppercase) {
+		return TaskStateRunning
+	}
+	if validation.ValidationChecks != nil {
+		for _, check := range *validation.ValidationChecks {
+			if !validationCheckState(check.ResultStatus).finished() {
+				return TaskStateRunning
+			}
+		}
+	}
+	if statusIn(stringValue(validation.ExecutionStatus), validationStatusFailed) {
+		return TaskStateFailed
+	}
+	return TaskStateSucceeded
+}
+
+func validationCheckState(status string) TaskState {
+	switch {
+	case statusIn(status, statusInProgressUppercase):
+		return TaskStateRunning
+	case statusIn(status, validationUnknown):
+		return TaskStatePending
+	case statusIn(status, validationStatusFailed):
+		return TaskStateFailed
+	default:
+		return TaskStateSucceeded
+	}
+}
@@ -0,0 +1,124 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/installer"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+func newTestInstallerClient(t *testing.T, server *fake_server.Server) *InstallerClient {
+	client := NewInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true, WithRequestLimits(0, 0))
+	if err := client.Connect(); err != nil {
+		t.Fatal("failed to connect to the fake installer", err)
+	}
+	return client
+}
+
+func TestWaitForSddcTask_success(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestInstallerClient(t, server)
+	sddcId := server.AddSddcTask(installer.SddcTask{},
+		fake_server.SddcStatusInProgress, fake_server.SddcStatusInProgress, fake_server.SddcStatusSuccessful)
+
+	tracker := client.NewSddcTaskTracker(context.Background(), sddcId)
+	tracker.pollingInterval = testPollingInterval
+	if err := tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+
+	if polls := server.RequestCount(http.MethodGet, "/v1/sddcs/"+sddcId); polls != 3 {
+		t.Fatalf("expected the deployment to be polled 3 times, got %d", polls)
+	}
+}
+
+func TestWaitForSddcTask_failedTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestInstallerClient(t, server)
+	sddcId := server.AddSddcTask(installer.SddcTask{}, fake_server.SddcStatusInProgress, fake_server.SddcStatusFailed)
+
+	tracker := client.NewSddcTaskTracker(context.Background(), sddcId)
+	tracker.pollingInterval = testPollingInterval
+	err := tracker.WaitForTask()
+	if err == nil || !strings.Contains(err.Error(), fake_server.SddcStatusFailed) {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestWaitForSddcTask_interrupted(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestInstallerClient(t, server)
+	sddcId := server.AddSddcTask(installer.SddcTask{}, fake_server.SddcStatusInProgress)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*testPollingInterval)
+	defer cancel()
+
+	tracker := client.NewSddcTaskTracker(ctx, sddcId)
+	tracker.pollingInterval = testPollingInterval
+	err := tracker.WaitForTask()
+
+	var interrupted *TaskInterruptedError
+	if !errors.As(err, &interrupted) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected a TaskInterruptedError", err)
+	}
+	if !strings.Contains(err.Error(), "Deploy vCenter Server") || !strings.Contains(err.Error(), "/v1/sddcs/"+sddcId) {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestWaitForValidation(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestInstallerClient(t, server)
+	server.ScriptNextSddcValidation(fake_server.ValidationInProgress, fake_server.ValidationFailed)
+
+	res, err := client.ApiClient.ValidateSddcSpecWithResponse(context.Background(), installer.SddcSpec{})
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	validation, _ := GetResponseAs[installer.Validation](res)
+	if validation == nil {
+		t.Fatal("unexpected response", res.Status())
+	}
+
+	// Failed validation checks are reported by the caller, the validation itself has completed
+	tracker := client.NewValidationTracker(context.Background(), *validation.Id)
+	tracker.pollingInterval = testPollingInterval
+	if err = tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+
+	if polls := server.RequestCount(http.MethodGet, "/v1/sddcs/validations/"+*validation.Id); polls != 2 {
+		t.Fatalf("expected the validation to be polled 2 times, got %d", polls)
+	}
+}
+
+func TestWaitForValidation_transientPollFailure(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := NewInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true, WithRetry(0, 0))
+	if err := client.Connect(); err != nil {
+		t.Fatal("failed to connect to the fake installer", err)
+	}
+
+	res, err := client.ApiClient.ValidateSddcSpecWithResponse(context.Background(), installer.SddcSpec{})
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	validation, _ := GetResponseAs[installer.Validation](res)
+	server.FailRequests(http.MethodGet, "/v1/sddcs/validations/"+*validation.Id, 2, http.StatusServiceUnavailable, vcf.Error{})
+
+	tracker := client.NewValidationTracker(context.Background(), *validation.Id)
+	tracker.pollingInterval = testPollingInterval
+	if err = tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
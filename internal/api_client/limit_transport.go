@@ -0,0 +1,136 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a rate limiter which allows a number of requests per second on average and
+// bursts of up to burst requests.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu       sync.Mutex
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(requestsPerSecond, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:     float64(requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take takes a token if one is available and returns 0, or returns how long it takes until
+// the next token is available.
+func (b *tokenBucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.lastFill).Seconds()*b.rate)
+	b.lastFill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// limitTransport is a http.RoundTripper which limits the rate of the requests sent to a server
+// and how many of them are in flight at the same time. A request is in flight until its
+// response body is closed.
+type limitTransport struct {
+	next     http.RoundTripper
+	bucket   *tokenBucket
+	inFlight chan struct{}
+}
+
+// newLimitTransport returns a limitTransport. A requestsPerSecond or maxConcurrentRequests of 0
+// disables the respective limit.
+func newLimitTransport(next http.RoundTripper, requestsPerSecond, burst, maxConcurrentRequests int) *limitTransport {
+	t := &limitTransport{next: next}
+	if requestsPerSecond > 0 {
+		t.bucket = newTokenBucket(requestsPerSecond, max(burst, 1))
+	}
+	if maxConcurrentRequests > 0 {
+		t.inFlight = make(chan struct{}, maxConcurrentRequests)
+	}
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.inFlight != nil {
+		select {
+		case t.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := sync.OnceFunc(func() {
+		if t.inFlight != nil {
+			<-t.inFlight
+		}
+	})
+
+	if t.bucket != nil {
+		if err := t.bucket.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if res.Body == nil || res.Body == http.NoBody {
+		release()
+		return res, nil
+	}
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// releasingBody releases the slot of a request in flight when the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (body *releasingBody) Close() error {
+	defer body.release()
+	return body.ReadCloser.Close()
+}
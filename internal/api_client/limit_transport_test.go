@@ -0,0 +1,102 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport records how many requests are in flight at most. A request is in flight
+// until its response body is closed.
+type countingTransport struct {
+	delay       time.Duration
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	inFlight := t.inFlight.Add(1)
+	for {
+		maxInFlight := t.maxInFlight.Load()
+		if inFlight <= maxInFlight || t.maxInFlight.CompareAndSwap(maxInFlight, inFlight) {
+			break
+		}
+	}
+	time.Sleep(t.delay)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       &closingBody{Reader: strings.NewReader("{}"), close: func() { t.inFlight.Add(-1) }},
+	}, nil
+}
+
+type closingBody struct {
+	io.Reader
+	close func()
+}
+
+func (body *closingBody) Close() error {
+	body.close()
+	return nil
+}
+
+func sendRequests(t *testing.T, tr http.RoundTripper, count int) {
+	var wg sync.WaitGroup
+	for range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, "https://sddc-manager.vsphere.local/v1/hosts", nil)
+			res, err := tr.RoundTrip(req)
+			if err != nil {
+				t.Error("received an unexpected error", err)
+				return
+			}
+			_ = res.Body.Close()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLimitTransport_maxConcurrentRequests(t *testing.T) {
+	next := &countingTransport{delay: 10 * time.Millisecond}
+
+	sendRequests(t, newLimitTransport(next, 0, 0, 3), 12)
+
+	if maxInFlight := next.maxInFlight.Load(); maxInFlight != 3 {
+		t.Fatalf("expected at most 3 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestLimitTransport_rate(t *testing.T) {
+	next := &countingTransport{}
+
+	start := time.Now()
+	sendRequests(t, newLimitTransport(next, 100, 5, 0), 15)
+
+	// The first 5 requests are a burst, the other 10 are sent at 100 requests per second
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatal("expected the requests to be rate limited, they took", elapsed)
+	}
+}
+
+func TestLimitTransport_contextDone(t *testing.T) {
+	tr := newLimitTransport(&countingTransport{}, 1, 1, 0)
+	sendRequests(t, tr, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://sddc-manager.vsphere.local/v1/hosts", nil)
+	if _, err := tr.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected the request to be interrupted while waiting, got", err)
+	}
+}
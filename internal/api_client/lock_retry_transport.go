@@ -0,0 +1,108 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+// lockConflictMessage is the start of the message of the 409 Conflict which the published API
+// specification documents for the requests rejected because another operation is in progress:
+// "Operation is in progress for Id {id}. Wait for the operation to complete."
+const lockConflictMessage = "operation is in progress"
+
+// lockRetryTransport is a http.RoundTripper which sends a request again after a while if SDDC
+// Manager rejected it because another operation is in progress on the same resources. Such
+// requests have not changed anything, so unlike other failed requests they can be retried
+// whatever their method.
+type lockRetryTransport struct {
+	next     http.RoundTripper
+	interval time.Duration
+	timeout  time.Duration
+}
+
+func newLockRetryTransport(next http.RoundTripper, interval, timeout time.Duration) *lockRetryTransport {
+	return &lockRetryTransport{
+		next:     next,
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+func (t *lockRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || isTokenRequest(req) || !isRewindable(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	deadline := time.Now().Add(t.timeout)
+	for attempt := 1; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		conflict, err := isLockConflict(req, res)
+		if err != nil {
+			return nil, err
+		}
+		if conflict == "" || time.Now().Add(t.interval).After(deadline) || req.Context().Err() != nil {
+			return res, nil
+		}
+
+		tflog.Warn(req.Context(), fmt.Sprintf("%s %s was rejected because another operation is in progress, "+
+			"retrying in %s (attempt %d): %s", req.Method, req.URL.Path, t.interval, attempt, conflict))
+		_ = res.Body.Close()
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(t.interval)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isLockConflict returns the message of the error of a response which has been rejected because
+// of a lock conflict, or an empty string if it has not. Only 409 Conflict responses are lock
+// conflicts, a request which failed otherwise, e.g. with a 5xx, may have started a workflow
+// already. The body of the response is kept.
+func isLockConflict(req *http.Request, res *http.Response) (string, error) {
+	if res.StatusCode != http.StatusConflict || res.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var vcfErr vcf.Error
+	if json.Unmarshal(body, &vcfErr) != nil || !isLockConflictError(vcfErr) {
+		tflog.Warn(req.Context(), fmt.Sprintf("%s %s was rejected with 409 Conflict and error code %q, "+
+			"which is not a known lock conflict, not retrying it: %s", req.Method, req.URL.Path,
+			stringValue(vcfErr.ErrorCode), stringValue(vcfErr.Message)))
+		return "", nil
+	}
+	return (&ApiError{Err: vcfErr}).Error(), nil
+}
+
+// isLockConflictError reports whether an error of the API is a rejection because another
+// operation is in progress on the same resources.
+func isLockConflictError(err vcf.Error) bool {
+	return strings.HasPrefix(strings.ToLower(stringValue(err.Message)), lockConflictMessage)
+}
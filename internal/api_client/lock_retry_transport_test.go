@@ -0,0 +1,108 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+var lockConflict = vcf.Error{
+	Message: ptr("Operation is in progress for Id host-1. Wait for the operation to complete."),
+}
+
+func commissionTestHost(t *testing.T, server *fake_server.Server, client *SddcManagerClient) *vcf.CommissionHostsResponse {
+	networkPoolId := server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	res, err := client.ApiClient.CommissionHostsWithResponse(context.Background(), []vcf.HostCommissionSpec{
+		{Fqdn: "esxi-1.vsphere.local", NetworkPoolId: networkPoolId, StorageType: "VSAN"},
+	})
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	return res
+}
+
+func TestLockRetryTransport(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithLockConflictRetry(time.Millisecond, time.Minute))
+	server.FailRequests(http.MethodPost, "/v1/hosts", 2, http.StatusConflict, lockConflict)
+
+	res := commissionTestHost(t, server, client)
+
+	if res.StatusCode() != http.StatusAccepted {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodPost, "/v1/hosts"); count != 3 {
+		t.Fatalf("expected 3 attempts, got %d", count)
+	}
+}
+
+func TestLockRetryTransport_timeout(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithLockConflictRetry(time.Hour, time.Millisecond))
+	server.FailRequests(http.MethodPost, "/v1/hosts", 1, http.StatusConflict, lockConflict)
+
+	res := commissionTestHost(t, server, client)
+
+	if res.StatusCode() != http.StatusConflict {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if _, vcfErr := GetResponseAs[vcf.Task](res); vcfErr == nil || *vcfErr.Message != *lockConflict.Message {
+		t.Fatal("expected the error of the rejection to be kept", vcfErr)
+	}
+}
+
+func TestLockRetryTransport_otherError(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithLockConflictRetry(time.Millisecond, time.Minute))
+	server.FailRequests(http.MethodPost, "/v1/hosts", 1, http.StatusBadRequest,
+		vcf.Error{ErrorCode: ptr("VCF_ERROR_INVALID_SPEC"), Message: ptr("Invalid host spec")})
+
+	res := commissionTestHost(t, server, client)
+
+	if res.StatusCode() != http.StatusBadRequest {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodPost, "/v1/hosts"); count != 1 {
+		t.Fatalf("expected a single attempt, got %d", count)
+	}
+}
+
+func TestLockRetryTransport_accountLocked(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithLockConflictRetry(time.Millisecond, time.Minute))
+	server.FailRequests(http.MethodPost, "/v1/hosts", 1, http.StatusConflict,
+		vcf.Error{ErrorCode: ptr("VCF_ERROR_LOCKED_ACCOUNT"), Message: ptr("The user account is locked")})
+
+	res := commissionTestHost(t, server, client)
+
+	if res.StatusCode() != http.StatusConflict {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodPost, "/v1/hosts"); count != 1 {
+		t.Fatalf("expected a single attempt, got %d", count)
+	}
+}
+
+func TestLockRetryTransport_serverError(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithLockConflictRetry(time.Millisecond, time.Minute))
+	server.FailRequests(http.MethodPost, "/v1/hosts", 1, http.StatusInternalServerError, lockConflict)
+
+	res := commissionTestHost(t, server, client)
+
+	if res.StatusCode() != http.StatusInternalServerError {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodPost, "/v1/hosts"); count != 1 {
+		t.Fatalf("expected a single attempt, got %d", count)
+	}
+}
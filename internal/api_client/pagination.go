@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/vcf"
+)
+
+// PageGetter retrieves a page of a list, page numbers start with 0. The getter of an endpoint
+// whose pages are numbered from 1 adds 1 to the page number.
+type PageGetter func(pageNumber int) (Response, error)
+
+// ListGetter retrieves a list from an endpoint which is not paged.
+type ListGetter func() (Response, error)
+
+// pageOf has the JSON representation shared by all PageOf* types of the SDDC Manager and
+// installer APIs, e.g. vcf.PageOfHost or installer.PageOfSddcTask.
+type pageOf[T interface{}] struct {
+	Elements     *[]T              `json:"elements,omitempty"`
+	PageMetadata *vcf.PageMetadata `json:"pageMetadata,omitempty"`
+}
+
+// GetAllPages retrieves the elements of every page of a list. If until is not nil, no further
+// pages are retrieved once it returns true for an element, that element is the last one returned.
+func GetAllPages[T interface{}](ctx context.Context, getPage PageGetter, until func(element T) bool) ([]T, error) {
+	var result []T
+	// The number of the first page in the page metadata, some endpoints number their pages from 1
+	var firstPage int
+	for pageNumber := 0; ; pageNumber++ {
+		res, err := getPage(pageNumber)
+		if err != nil {
+			return nil, err
+		}
+		page, vcfErr := GetResponseAs[pageOf[T]](res)
+		if vcfErr != nil {
+			LogError(vcfErr, ctx)
+			return nil, NewApiError(vcfErr)
+		}
+		if page == nil {
+			return nil, fmt.Errorf("failed to parse page %d of the list", pageNumber)
+		}
+
+		if metadata := page.PageMetadata; pageNumber == 0 && metadata != nil && metadata.PageNumber != nil {
+			firstPage = int(*metadata.PageNumber)
+		}
+		// An endpoint which does not support paging returns the first page again
+		if pageNumber > 0 && !isPage(page.PageMetadata, firstPage+pageNumber) {
+			tflog.Debug(ctx, fmt.Sprintf("requested page %d of the list but received another page, ignoring it", pageNumber))
+			return result, nil
+		}
+
+		if page.Elements == nil || len(*page.Elements) == 0 {
+			return result, nil
+		}
+		for _, element := range *page.Elements {
+			result = append(result, element)
+			if until != nil && until(element) {
+				return result, nil
+			}
+		}
+
+		if metadata := page.PageMetadata; metadata == nil || metadata.TotalPages == nil ||
+			pageNumber+1 >= int(*metadata.TotalPages) {
+			return result, nil
+		}
+	}
+}
+
+// FindInPages returns the first element of a list for which match returns true, or nil if there
+// is no such element. Pages are only retrieved until the element has been found.
+func FindInPages[T interface{}](ctx context.Context, getPage PageGetter, match func(element T) bool) (*T, error) {
+	elements, err := GetAllPages(ctx, getPage, match)
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 || !match(elements[len(elements)-1]) {
+		return nil, nil
+	}
+	return &elements[len(elements)-1], nil
+}
+
+// GetList retrieves the elements of a list from an endpoint which is not paged.
+func GetList[T interface{}](ctx context.Context, getList ListGetter) ([]T, error) {
+	res, err := getList()
+	if err != nil {
+		return nil, err
+	}
+	list, vcfErr := GetResponseAs[pageOf[T]](res)
+	if vcfErr != nil {
+		LogError(vcfErr, ctx)
+		return nil, NewApiError(vcfErr)
+	}
+	if list == nil {
+		return nil, fmt.Errorf("failed to parse the list")
+	}
+	if list.Elements == nil {
+		return nil, nil
+	}
+	return *list.Elements, nil
+}
+
+// FindInList returns the first element of a list from an endpoint which is not paged for which
+// match returns true, or nil if there is no such element.
+func FindInList[T interface{}](ctx context.Context, getList ListGetter, match func(element T) bool) (*T, error) {
+	elements, err := GetList[T](ctx, getList)
+	if err != nil {
+		return nil, err
+	}
+	for i := range elements {
+		if match(elements[i]) {
+			return &elements[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// isPage reports whether the metadata belongs to the page with the given number.
+func isPage(metadata *vcf.PageMetadata, pageNumber int) bool {
+	return metadata != nil && metadata.PageNumber != nil && int(*metadata.PageNumber) == pageNumber
+}
@@ -0,0 +1,163 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+func addTestHosts(server *fake_server.Server, count int) {
+	for i := 1; i <= count; i++ {
+		server.AddHost(vcf.Host{
+			Id:   ptr(fmt.Sprintf("host-%d", i)),
+			Fqdn: ptr(fmt.Sprintf("esxi-%d.vsphere.local", i)),
+		})
+	}
+}
+
+func getHostsPage(client *SddcManagerClient) PageGetter {
+	return func(pageNumber int) (Response, error) {
+		return client.ApiClient.GetHostsWithResponse(context.Background(), &vcf.GetHostsParams{Page: &pageNumber})
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	addTestHosts(server, 5)
+	server.SetPageSize(2)
+
+	hosts, err := GetAllPages[vcf.Host](context.Background(), getHostsPage(client), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if len(hosts) != 5 {
+		t.Fatalf("expected the hosts of all pages, got %d", len(hosts))
+	}
+	if requests := server.RequestCount(http.MethodGet, "/v1/hosts"); requests != 3 {
+		t.Fatalf("expected 3 pages to be retrieved, got %d", requests)
+	}
+}
+
+func TestGetAllPages_notPaged(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	addTestHosts(server, 5)
+	server.SetPageSize(2)
+
+	// An endpoint which ignores the page number returns the first page for every request
+	hosts, err := GetAllPages[vcf.Host](context.Background(), func(_ int) (Response, error) {
+		return client.ApiClient.GetHostsWithResponse(context.Background(), nil)
+	}, nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected the hosts of the first page only, got %d", len(hosts))
+	}
+}
+
+func TestGetAllPages_fromOne(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	for i := 1; i <= 5; i++ {
+		server.AddPersonality(vcf.Personality{PersonalityName: ptr(fmt.Sprintf("personality-%d", i))})
+	}
+
+	// The pages of personalities are numbered from 1
+	personalities, err := GetAllPages[vcf.Personality](context.Background(), func(pageNumber int) (Response, error) {
+		page, size := pageNumber+1, 2
+		return client.ApiClient.GetPersonalitiesWithResponse(context.Background(), &vcf.GetPersonalitiesParams{Page: &page, Size: &size})
+	}, nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if len(personalities) != 5 || *personalities[4].PersonalityName != "personality-5" {
+		t.Fatalf("expected the personalities of all pages, got %d", len(personalities))
+	}
+	if requests := server.RequestCount(http.MethodGet, "/v1/personalities"); requests != 3 {
+		t.Fatalf("expected 3 pages to be retrieved, got %d", requests)
+	}
+}
+
+func TestGetAllPages_error(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(0, 0))
+	addTestHosts(server, 5)
+	server.SetPageSize(2)
+	server.FailRequests(http.MethodGet, "/v1/hosts", 1, http.StatusBadRequest,
+		vcf.Error{Message: ptr("Invalid page"), ReferenceToken: ptr("A1B2C3")})
+
+	hosts, err := GetAllPages[vcf.Host](context.Background(), getHostsPage(client), nil)
+	if err == nil || err.Error() != "Invalid page" || hosts != nil {
+		t.Fatal("unexpected result", hosts, err)
+	}
+}
+
+func TestFindInPages(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	addTestHosts(server, 5)
+	server.SetPageSize(2)
+
+	host, err := FindInPages(context.Background(), getHostsPage(client), func(host vcf.Host) bool {
+		return *host.Fqdn == "esxi-3.vsphere.local"
+	})
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if host == nil || *host.Id != "host-3" {
+		t.Fatal("expected host-3 to be found", host)
+	}
+	if requests := server.RequestCount(http.MethodGet, "/v1/hosts"); requests != 2 {
+		t.Fatalf("expected no pages to be retrieved after the match, got %d requests", requests)
+	}
+}
+
+func TestFindInPages_notFound(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	addTestHosts(server, 5)
+	server.SetPageSize(2)
+
+	host, err := FindInPages(context.Background(), getHostsPage(client), func(host vcf.Host) bool {
+		return *host.Fqdn == "esxi-6.vsphere.local"
+	})
+	if err != nil || host != nil {
+		t.Fatal("unexpected result", host, err)
+	}
+	if requests := server.RequestCount(http.MethodGet, "/v1/hosts"); requests != 3 {
+		t.Fatalf("expected all pages to be searched, got %d requests", requests)
+	}
+}
+
+func TestFindInList(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	id := server.AddNetworkPool(vcf.NetworkPool{Name: "ops-pool"})
+
+	pool, err := FindInList(context.Background(), func() (Response, error) {
+		return client.ApiClient.GetNetworkPoolWithResponse(context.Background())
+	}, func(pool vcf.NetworkPool) bool {
+		return pool.Name == "ops-pool"
+	})
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if pool == nil || *pool.Id != id {
+		t.Fatal("expected ops-pool to be found", pool)
+	}
+	if requests := server.RequestCount(http.MethodGet, "/v1/network-pools"); requests != 1 {
+		t.Fatalf("expected the list to be retrieved once, got %d requests", requests)
+	}
+}
@@ -0,0 +1,173 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryBaseBackoff is the delay before the first retry, it doubles with every attempt.
+const retryBaseBackoff = time.Second
+
+// retryTransport is a http.RoundTripper which retries idempotent requests that failed with
+// a transient error, e.g. a connection reset or a 502/503 from the SDDC Manager reverse proxy.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxBackoff time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxBackoff: maxBackoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetryableRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !shouldRetry(req.Context(), res, err) {
+			return res, err
+		}
+
+		delay := t.backoff(attempt, res)
+		tflog.Warn(req.Context(), fmt.Sprintf("%s %s failed with %s, retrying in %s (attempt %d of %d)",
+			req.Method, req.URL.Path, describeFailure(res, err), delay, attempt+1, t.maxRetries))
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt. The Retry-After header is honoured up to
+// maxBackoff if the server sent one, otherwise the delay grows exponentially with full jitter up
+// to maxBackoff.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return max(min(delay, t.maxBackoff), 0)
+		}
+	}
+
+	maxDelay := retryBaseBackoff << min(attempt, 30)
+	if maxDelay > t.maxBackoff || maxDelay <= 0 {
+		maxDelay = t.maxBackoff
+	}
+	if maxDelay <= 0 {
+		return 0
+	}
+	return rand.N(maxDelay) + 1
+}
+
+// isRetryableRequest reports whether the request can safely be sent more than once.
+// Apart from GET requests only the token endpoints are retried, as no other request of the
+// SDDC Manager and installer APIs is idempotent.
+func isRetryableRequest(req *http.Request) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+	return isTokenRequest(req) && isRewindable(req)
+}
+
+// isRewindable reports whether the body of the request can be sent again.
+func isRewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isTransientError(err)
+	}
+	return isTransientStatusCode(res.StatusCode)
+}
+
+// isTransientError reports whether a request failed for a reason that is likely to go away,
+// such as the connection being reset while SDDC Manager services restart.
+func isTransientError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isTransientStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rewindRequest returns a copy of the request with a fresh body, so that it can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+func describeFailure(res *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return res.Status
+}
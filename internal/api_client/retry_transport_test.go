@@ -0,0 +1,165 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+func TestRetryTransport_connectionReset(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.ResetConnections(http.MethodGet, "/v1/domains", 2)
+
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodGet, "/v1/domains"); count != 3 {
+		t.Fatalf("expected 3 attempts, got %d", count)
+	}
+}
+
+func TestRetryTransport_serviceUnavailable(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.FailRequests(http.MethodGet, "/v1/domains", 2, http.StatusServiceUnavailable, vcf.Error{})
+
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+}
+
+func TestRetryTransport_retriesExhausted(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(2, time.Millisecond))
+	server.FailRequests(http.MethodGet, "/v1/domains", 5, http.StatusBadGateway, vcf.Error{})
+
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusBadGateway {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodGet, "/v1/domains"); count != 3 {
+		t.Fatalf("expected 3 attempts, got %d", count)
+	}
+}
+
+func TestRetryTransport_nonIdempotentRequest(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.FailRequests(http.MethodPost, "/v1/network-pools", 1, http.StatusServiceUnavailable, vcf.Error{})
+
+	res, err := client.ApiClient.CreateNetworkPoolWithResponse(context.Background(), vcf.NetworkPool{Name: "pool"})
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusServiceUnavailable {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if count := server.RequestCount(http.MethodPost, "/v1/network-pools"); count != 1 {
+		t.Fatalf("expected a single attempt, got %d", count)
+	}
+}
+
+func TestRetryTransport_tokenEndpoint(t *testing.T) {
+	server := fake_server.NewServer(t)
+	server.ResetConnections(http.MethodPost, "/v1/tokens", 1)
+
+	// The token request has a body, which must be sent again on retry
+	newTestSddcManagerClient(t, server)
+
+	if count := server.RequestCount(http.MethodPost, "/v1/tokens"); count != 2 {
+		t.Fatalf("expected 2 attempts, got %d", count)
+	}
+}
+
+func TestRetryTransport_retryAfter(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(DefaultMaxRetries, time.Minute))
+	server.ThrottleRequests(http.MethodGet, "/v1/domains", 1, "1")
+
+	start := time.Now()
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected the Retry-After header to be honoured, retried after %s", elapsed)
+	}
+}
+
+func TestRetryTransport_retryAfterCapped(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(3, 100*time.Millisecond))
+	server.ThrottleRequests(http.MethodGet, "/v1/domains", 1, "3600")
+
+	start := time.Now()
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > 5*time.Second {
+		t.Fatalf("expected the Retry-After header to be capped by the maximum backoff, retried after %s", elapsed)
+	}
+}
+
+func TestRetryTransport_cancelledContext(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(3, time.Minute))
+	server.ThrottleRequests(http.MethodGet, "/v1/domains", 1, "60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.ApiClient.GetDomainsWithResponse(ctx, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-5", 0, true},
+		{"Wed, 01 Jan 2025 00:00:10 GMT", 10 * time.Second, true},
+		{"Tue, 31 Dec 2024 23:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, test := range tests {
+		delay, ok := parseRetryAfter(test.value, now)
+		if ok != test.ok || delay != test.expected {
+			t.Errorf("parseRetryAfter(%q) = %s, %t; expected %s, %t", test.value, delay, ok, test.expected, test.ok)
+		}
+	}
+}
@@ -6,100 +6,146 @@ package api_client
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	"github.com/vmware/vcf-sdk-go/vcf"
 )
 
+// errAccessTokenExpired is returned by the requests of a client which authenticates with a
+// pre-issued access token once the token has expired or has been revoked.
+var errAccessTokenExpired = errors.New("the access token has expired or has been revoked, " +
+	"provide a new one in sddc_manager_access_token")
+
 // SddcManagerClient model that represents properties to authenticate against VCF instance.
 type SddcManagerClient struct {
 	username           string
 	password           string
 	sddcManagerUrl     string
 	providerVersion    string
-	accessToken        *string
 	ApiClient          *vcf.ClientWithResponses
 	allowUnverifiedTls bool
-	lastRefreshTime    time.Time
-	isRefreshing       bool
+	config             clientConfig
+	tokens             *tokenManager
 }
 
 // NewSddcManagerClient constructs new Client instance with vcf credentials.
-func NewSddcManagerClient(username, password, url, providerVersion string, allowUnverifiedTls bool) *SddcManagerClient {
+func NewSddcManagerClient(username, password, url, providerVersion string, allowUnverifiedTls bool,
+	opts ...ClientOption) *SddcManagerClient {
 	return &SddcManagerClient{
 		username:           username,
 		password:           password,
 		sddcManagerUrl:     url,
 		providerVersion:    providerVersion,
 		allowUnverifiedTls: allowUnverifiedTls,
-		lastRefreshTime:    time.Now(),
-		isRefreshing:       false,
+		config:             newClientConfig(opts),
 	}
 }
 
-func (sddcManagerClient *SddcManagerClient) authEditor(ctx context.Context, req *http.Request) error {
-	// Refresh the access token every 20 minutes so that SDK operations won't start to
-	// fail with 401, 403 because of token expiration, during long-running tasks
-	if time.Since(sddcManagerClient.lastRefreshTime) > 20*time.Minute &&
-		!sddcManagerClient.isRefreshing {
-		err := sddcManagerClient.Connect()
-		if err != nil {
-			return err
-		}
-	}
-
-	if sddcManagerClient.accessToken != nil {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", *sddcManagerClient.accessToken))
-	}
-
+func (sddcManagerClient *SddcManagerClient) requestEditor(ctx context.Context, req *http.Request) error {
 	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", constants.ProviderName, sddcManagerClient.providerVersion))
 	req.Header.Set("Content-Type", "application/json")
 
 	return nil
 }
 
-func (sddcManagerClient *SddcManagerClient) Connect() error {
-	sddcManagerClient.isRefreshing = true
+// Init creates the API client without logging in to SDDC Manager. The client logs in on its
+// first request and renews the access token transparently afterward, so an unreachable
+// SDDC Manager is only reported by the requests sent to it.
+func (sddcManagerClient *SddcManagerClient) Init() error {
+	if sddcManagerClient.ApiClient != nil {
+		return nil
+	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: sddcManagerClient.allowUnverifiedTls},
+	tokens := &tokenManager{}
+	httpClient, err := sddcManagerClient.config.newHttpClient(sddcManagerClient.allowUnverifiedTls, tokens)
+	if err != nil {
+		return err
 	}
-	httpClient := &http.Client{Transport: tr}
 	client, err := vcf.NewClientWithResponses(fmt.Sprintf("https://%s", sddcManagerClient.sddcManagerUrl),
-		vcf.WithRequestEditorFn(sddcManagerClient.authEditor), vcf.WithHTTPClient(httpClient))
+		vcf.WithRequestEditorFn(sddcManagerClient.requestEditor), vcf.WithHTTPClient(httpClient))
 	if err != nil {
 		return err
 	}
 
+	tokens.login = func(ctx context.Context) (tokenPair, error) {
+		return sddcManagerClient.createToken(ctx, client)
+	}
+	tokens.refresh = func(ctx context.Context, refreshToken string) (string, error) {
+		return sddcManagerClient.refreshAccessToken(ctx, client, refreshToken)
+	}
+	if accessToken := sddcManagerClient.config.accessToken; accessToken != "" {
+		// A pre-issued access token comes without a refresh token and cannot be renewed
+		tokens.login = func(_ context.Context) (tokenPair, error) {
+			return tokenPair{}, errAccessTokenExpired
+		}
+		tokens.setIssuedAccessToken(accessToken)
+	}
+
 	sddcManagerClient.ApiClient = client
+	sddcManagerClient.tokens = tokens
+	return nil
+}
+
+// Connect creates the API client, if Init has not been called yet, and logs in to SDDC Manager right
+// away, which reports wrong credentials or an unreachable SDDC Manager immediately.
+func (sddcManagerClient *SddcManagerClient) Connect() error {
+	if err := sddcManagerClient.Init(); err != nil {
+		return err
+	}
+
+	_, err := sddcManagerClient.tokens.AccessToken(context.Background())
+	return err
+}
 
+func (sddcManagerClient *SddcManagerClient) createToken(ctx context.Context, client *vcf.ClientWithResponses) (tokenPair, error) {
 	tokenCreationSpec := vcf.TokenCreationSpec{
 		Username: &sddcManagerClient.username,
 		Password: &sddcManagerClient.password,
 	}
+	if apiKey := sddcManagerClient.config.apiKey; apiKey != "" {
+		tokenCreationSpec = vcf.TokenCreationSpec{ApiKey: &apiKey}
+	}
 
-	res, err := client.CreateTokenWithResponse(context.Background(), tokenCreationSpec)
+	res, err := client.CreateTokenWithResponse(ctx, tokenCreationSpec)
 	if err != nil {
-		return err
+		return tokenPair{}, err
 	}
 
-	tokenPair, vcfErr := GetResponseAs[vcf.TokenPair](res)
-	if vcfErr != nil {
-		return errors.New(*vcfErr.Message)
+	tokens, vcfErr := GetResponseAs[vcf.TokenPair](res)
+	if vcfErr != nil && vcfErr.Message != nil {
+		return tokenPair{}, NewApiError(vcfErr)
+	}
+	if tokens == nil || tokens.AccessToken == nil {
+		return tokenPair{}, fmt.Errorf("failed to create an access token: %s", res.Status())
 	}
-	sddcManagerClient.accessToken = tokenPair.AccessToken
-	sddcManagerClient.lastRefreshTime = time.Now()
-	sddcManagerClient.isRefreshing = false
 
-	return nil
+	result := tokenPair{accessToken: *tokens.AccessToken}
+	if tokens.RefreshToken != nil && tokens.RefreshToken.Id != nil {
+		result.refreshToken = *tokens.RefreshToken.Id
+	}
+	return result, nil
+}
+
+func (sddcManagerClient *SddcManagerClient) refreshAccessToken(ctx context.Context, client *vcf.ClientWithResponses, refreshToken string) (string, error) {
+	res, err := client.RefreshAccessTokenWithResponse(ctx, refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	accessToken, vcfErr := GetResponseAs[string](res)
+	if vcfErr != nil && vcfErr.Message != nil {
+		return "", NewApiError(vcfErr)
+	}
+	if accessToken == nil || *accessToken == "" {
+		return "", fmt.Errorf("failed to refresh the access token: %s", res.Status())
+	}
+	return *accessToken, nil
 }
 
 func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTask(ctx context.Context, taskId, resourceType string) (string, error) {
@@ -107,7 +153,7 @@ func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTask(ctx
 	if err != nil {
 		return "", err
 	}
-	if len(*task.Resources) == 0 {
+	if task.Resources == nil || len(*task.Resources) == 0 {
 		return "", fmt.Errorf("no resources associated with Task with ID %q", taskId)
 	}
 	for _, resource := range *task.Resources {
@@ -118,14 +164,39 @@ func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTask(ctx
 	return "", fmt.Errorf("task %q did not contain resources of type %q", taskId, resourceType)
 }
 
+// GetResourceIdAssociatedWithTaskByFqdn returns the ID of the resource of a type with the given
+// FQDN among the resources of a task. Used for tasks which operate on several resources at once.
+func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTaskByFqdn(ctx context.Context, taskId, resourceType, fqdn string) (string, error) {
+	task, err := sddcManagerClient.getTask(ctx, taskId)
+	if err != nil {
+		return "", err
+	}
+	if task.Resources == nil || len(*task.Resources) == 0 {
+		return "", fmt.Errorf("no resources associated with Task with ID %q", taskId)
+	}
+	for _, resource := range *task.Resources {
+		if resource.Type == resourceType && resource.Fqdn != nil && strings.EqualFold(*resource.Fqdn, fqdn) {
+			return resource.ResourceId, nil
+		}
+	}
+	return "", fmt.Errorf("task %q did not contain a resource of type %q with FQDN %q", taskId, resourceType, fqdn)
+}
+
 func (sddcManagerClient *SddcManagerClient) getTask(ctx context.Context, taskId string) (*vcf.Task, error) {
 	apiClient := sddcManagerClient.ApiClient
 	res, err := apiClient.GetTaskWithResponse(ctx, taskId)
-	task, vcfErr := GetResponseAs[vcf.Task](res)
-	if err != nil || vcfErr != nil {
-		log.Println("error = ", err)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("failed to get Task with ID %q: %s", taskId, err))
 		return nil, err
 	}
+	task, vcfErr := GetResponseAs[vcf.Task](res)
+	if vcfErr != nil {
+		LogError(vcfErr, ctx)
+		return nil, NewApiError(vcfErr)
+	}
+	if task == nil {
+		return nil, fmt.Errorf("failed to get Task with ID %q: %s", taskId, res.Status())
+	}
 
 	return task, nil
 }
@@ -1,11 +1,16 @@
 package api_client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
 )
 
 func TestGetResponseAs_pos(t *testing.T) {
@@ -57,3 +62,105 @@ func TestGetResponseAs_neg(t *testing.T) {
 		t.Fatal("response does not contain correct payload")
 	}
 }
+
+func TestSddcManagerClientConnect_pos(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if _, vcfErr := GetResponseAs[vcf.PageOfDomain](res); vcfErr != nil {
+		t.Fatal("received an unexpected error", *vcfErr.Message)
+	}
+}
+
+func TestSddcManagerClientConnect_neg(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := NewSddcManagerClient(fake_server.Username, "wrong", server.Host(), "test", true)
+
+	err := client.Connect()
+	if err == nil {
+		t.Fatal("error is nil")
+	}
+	if err.Error() != "Invalid username or password" {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestSddcManagerClientInit(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := NewSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true)
+
+	if err := client.Init(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if logins := server.RequestCount(http.MethodPost, "/v1/tokens"); logins != 0 {
+		t.Fatal("expected the client not to log in before the first request")
+	}
+
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+	if logins := server.RequestCount(http.MethodPost, "/v1/tokens"); logins != 1 {
+		t.Fatalf("expected the client to log in on the first request, got %d logins", logins)
+	}
+}
+
+func TestSddcManagerClientInit_wrongCredentials(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := NewSddcManagerClient(fake_server.Username, "wrong", server.Host(), "test", true)
+
+	if err := client.Init(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+
+	_, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "failed to authenticate: Invalid username or password") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestGetResourceIdAssociatedWithTaskByFqdn_unknownTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+
+	_, err := client.GetResourceIdAssociatedWithTaskByFqdn(context.Background(), "unknown", "ESXI", "esxi-1.vsphere.local")
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "Task with ID unknown not found") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestInstallerClientConnect(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := NewInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true)
+
+	if err := client.Connect(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	res, err := client.ApiClient.GetSddcTasksWithResponse(context.Background())
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+}
+
+func TestInstallerGetResourceIdAssociatedWithTask_unknownTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestInstallerClient(t, server)
+
+	_, err := client.GetResourceIdAssociatedWithTask(context.Background(), "unknown", "SDDC")
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "Task with ID unknown not found") {
+		t.Fatal("unexpected error", err)
+	}
+}
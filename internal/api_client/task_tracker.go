@@ -8,17 +8,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/vcf-sdk-go/vcf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
 )
 
 const (
 	// Default polling interval for task tracking.
 	defaultPollingInterval = 20 * time.Second
 
+	// Default polling interval for validations, which complete within minutes.
+	defaultValidationPollingInterval = 10 * time.Second
+
+	// Number of consecutive polls that may fail with a transient error before giving up on a task.
+	maxFailedPolls = 5
+
+	// How long to wait for SDDC Manager to accept the cancellation of an interrupted task.
+	cancelTaskTimeout = 30 * time.Second
+
 	// Task status constants.
 	statusInProgress          = "In Progress"
 	statusInProgressUppercase = "IN_PROGRESS"
@@ -28,100 +42,319 @@ const (
 	statusNotApplicable       = "NOT_APPLICABLE"
 )
 
+// TaskInterruptedError is returned by WaitForTask when waiting for a task has been interrupted
+// while the task may still be running on SDDC Manager.
+type TaskInterruptedError struct {
+	TaskId  string
+	message string
+	cause   error
+}
+
+func (e *TaskInterruptedError) Error() string {
+	return e.message
+}
+
+func (e *TaskInterruptedError) Unwrap() error {
+	return e.cause
+}
+
+// TaskState classifies the status of a task or subtask, as the status values differ between
+// SDDC Manager, the installer and validations.
+type TaskState int
+
+const (
+	TaskStatePending TaskState = iota
+	TaskStateRunning
+	TaskStateSucceeded
+	TaskStateFailed
+)
+
+// finished reports whether a task or subtask in this state has come to an end.
+func (s TaskState) finished() bool {
+	return s == TaskStateSucceeded || s == TaskStateFailed
+}
+
+// TrackedTask is the state of a task as reported by a TaskSource.
+type TrackedTask struct {
+	Id     string
+	Name   string
+	Type   string
+	Status string
+	State  TaskState
+	// Message describes the progress of a task without subtasks.
+	Message  string
+	Errors   []vcf.Error
+	Subtasks []TrackedSubtask
+}
+
+// firstError returns the error which has most likely caused the task to fail: the first error
+// of a failed subtask, which is more specific than an error of the task itself.
+func (task TrackedTask) firstError() error {
+	for _, subtask := range task.Subtasks {
+		if subtask.State == TaskStateFailed && len(subtask.Errors) > 0 {
+			return NewApiError(&subtask.Errors[0])
+		}
+	}
+	if len(task.Errors) > 0 {
+		return NewApiError(&task.Errors[0])
+	}
+	return nil
+}
+
+// TrackedSubtask is the state of a step of a TrackedTask.
+type TrackedSubtask struct {
+	Description string
+	Status      string
+	State       TaskState
+	Errors      []vcf.Error
+}
+
+// TaskSource retrieves the tasks followed by a TaskTracker from one of the APIs.
+type TaskSource interface {
+	// GetTask retrieves the current state of a task. Failures which are worth polling again
+	// should be returned as a *TaskPollError.
+	GetTask(ctx context.Context, taskId string) (*TrackedTask, error)
+	// DescribeRunningTask tells where to follow a task the tracker has stopped waiting for.
+	DescribeRunningTask(taskId string) string
+}
+
+// taskCanceller is implemented by task sources whose tasks can be cancelled.
+type taskCanceller interface {
+	CancelTask(ctx context.Context, taskId string) error
+}
+
+// TaskPollError is returned by a TaskSource when a task could not be retrieved.
+type TaskPollError struct {
+	Err error
+	// Transient reports whether the failure is likely to go away and the task should be polled again.
+	Transient bool
+}
+
+func (e *TaskPollError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TaskPollError) Unwrap() error {
+	return e.Err
+}
+
 type TaskTracker struct {
 	ctx             context.Context
-	client          *vcf.ClientWithResponses
+	source          TaskSource
 	taskId          string
 	pollingInterval time.Duration
 	completedTasks  map[string]bool
+	failedPolls     int
+	lastSubtask     string
+
+	// cancelOnInterrupt controls whether the task is cancelled on SDDC Manager when waiting
+	// for it is interrupted, otherwise it is left running.
+	cancelOnInterrupt bool
 }
 
-func NewTaskTracker(ctx context.Context, client *vcf.ClientWithResponses, taskId string) *TaskTracker {
+// NewTaskTrackerForSource creates a tracker for a task retrieved from the given source.
+func NewTaskTrackerForSource(ctx context.Context, source TaskSource, taskId string) *TaskTracker {
 	return &TaskTracker{
 		ctx:             ctx,
-		client:          client,
+		source:          source,
 		taskId:          taskId,
 		pollingInterval: defaultPollingInterval,
 		completedTasks:  make(map[string]bool),
 	}
 }
 
+func NewTaskTracker(ctx context.Context, client *vcf.ClientWithResponses, taskId string) *TaskTracker {
+	return NewTaskTrackerForSource(ctx, &sddcManagerTaskSource{client: client}, taskId)
+}
+
 func NewTaskTrackerWithCustomPollingInterval(ctx context.Context, client *vcf.ClientWithResponses, taskId string, pollingInterval time.Duration) *TaskTracker {
 	tracker := NewTaskTracker(ctx, client, taskId)
 	tracker.pollingInterval = pollingInterval
 	return tracker
 }
 
-func (t *TaskTracker) WaitForTask() error {
+// NewTaskTracker creates a tracker for a task of this SDDC Manager, which honours the
+// cancellation and polling settings of the client.
+func (sddcManagerClient *SddcManagerClient) NewTaskTracker(ctx context.Context, taskId string) *TaskTracker {
+	tracker := NewTaskTracker(ctx, sddcManagerClient.ApiClient, taskId)
+	tracker.cancelOnInterrupt = sddcManagerClient.config.cancelTasksOnInterrupt
+	tracker.pollingInterval = sddcManagerClient.config.getTaskPollingInterval(defaultPollingInterval)
+	return tracker
+}
+
+// NewTaskTrackerWithCustomPollingInterval creates a tracker for a task of this SDDC Manager,
+// which polls the task at the given interval.
+func (sddcManagerClient *SddcManagerClient) NewTaskTrackerWithCustomPollingInterval(ctx context.Context, taskId string, pollingInterval time.Duration) *TaskTracker {
+	tracker := sddcManagerClient.NewTaskTracker(ctx, taskId)
+	tracker.pollingInterval = sddcManagerClient.config.getTaskPollingInterval(pollingInterval)
+	return tracker
+}
+
+// NewSharedTaskTracker creates a tracker for a task of this SDDC Manager which operates on the
+// resources of several Terraform resources. The task is left running when waiting for it is
+// interrupted, as cancelling it would fail the other resources as well.
+func (sddcManagerClient *SddcManagerClient) NewSharedTaskTracker(ctx context.Context, taskId string, pollingInterval time.Duration) *TaskTracker {
+	tracker := NewTaskTrackerWithCustomPollingInterval(ctx, sddcManagerClient.ApiClient, taskId,
+		sddcManagerClient.config.getTaskPollingInterval(pollingInterval))
+	tracker.cancelOnInterrupt = false
+	return tracker
+}
+
+// NewHostCommissionValidationTracker creates a tracker for a validation of host commission specs
+// of this SDDC Manager. The tracker waits for all validation checks to finish, the result of the
+// validation has to be retrieved afterward.
+func (sddcManagerClient *SddcManagerClient) NewHostCommissionValidationTracker(ctx context.Context, validationId string) *TaskTracker {
+	tracker := NewTaskTrackerForSource(ctx, &hostCommissionValidationSource{client: sddcManagerClient.ApiClient}, validationId)
+	tracker.pollingInterval = sddcManagerClient.config.getTaskPollingInterval(defaultValidationPollingInterval)
+	return tracker
+}
+
+// WaitForTask polls the task until it has completed and returns an error if it has failed.
+// Waiting is recorded as a span, with an event for every subtask which has completed.
+func (t *TaskTracker) WaitForTask() (err error) {
+	ctx, span := tracing.StartSpan(t.ctx, "WaitForTask", tracing.AttributeTaskId.String(t.taskId))
+	defer func() { tracing.EndSpan(span, err) }()
+	t.ctx = ctx
+
 	ticker := time.NewTicker(t.pollingInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-t.ctx.Done():
+			return t.interrupt()
 		case <-ticker.C:
-			task, err := t.getTask()
-			if err != nil {
-				LogError(err, t.ctx)
-				return errors.New(*err.Message)
+			if done, err := t.checkTask(); done {
+				return t.result(err)
 			}
+		}
+	}
+}
 
-			t.logTask(*task)
-
-			switch *task.Status {
-			case statusInProgress, statusInProgressUppercase, statusPending:
-				continue
-			case statusFailed, statusCancelled:
-				errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q is in state %s",
-					*task.Id, *task.Name, *task.Type, *task.Status)
-				tflog.Error(t.ctx, errorMsg)
-
-				return errors.New(errorMsg)
-			default:
-				tflog.Info(t.ctx, fmt.Sprintf("Task with ID = %s , Name: %q Type: %q is in state %s",
-					*task.Id, *task.Name, *task.Type, *task.Status))
-				return nil
-			}
+func (t *TaskTracker) result(err error) error {
+	// The poll failed because the context was cancelled while it was in flight
+	if err != nil && t.ctx.Err() != nil {
+		return t.interrupt()
+	}
+	return err
+}
+
+// interrupt is called when the context of the tracker is done, either because the resource
+// timeout has expired or because Terraform has been interrupted. Depending on the settings the
+// task is cancelled or left running.
+func (t *TaskTracker) interrupt() error {
+	message := fmt.Sprintf("stopped waiting for task with ID %s", t.taskId)
+	if t.lastSubtask != "" {
+		message = fmt.Sprintf("%s at subtask %q", message, t.lastSubtask)
+	}
+
+	canceller, ok := t.source.(taskCanceller)
+	if !t.cancelOnInterrupt || !ok {
+		return &TaskInterruptedError{
+			TaskId:  t.taskId,
+			message: fmt.Sprintf("%s: %s. %s", message, context.Cause(t.ctx), t.source.DescribeRunningTask(t.taskId)),
+			cause:   context.Cause(t.ctx),
 		}
 	}
+
+	// The context of the tracker is done, the cancellation needs a context of its own
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(t.ctx), cancelTaskTimeout)
+	defer cancel()
+
+	if err := canceller.CancelTask(ctx, t.taskId); err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Failed to cancel task with ID = %s: %s", t.taskId, err))
+		return &TaskInterruptedError{
+			TaskId: t.taskId,
+			message: fmt.Sprintf("%s: %s. Cancelling the task failed, it may still be running on SDDC Manager: %s",
+				message, context.Cause(t.ctx), err),
+			cause: context.Cause(t.ctx),
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Task with ID = %s has been cancelled", t.taskId))
+	return fmt.Errorf("%s: %w. The task has been cancelled", message, context.Cause(t.ctx))
 }
 
-func (t *TaskTracker) getTask() (*vcf.Task, *vcf.Error) {
-	res, _ := t.client.GetTaskWithResponse(t.ctx, t.taskId)
+// checkTask retrieves the task and reports whether it has reached a final state.
+// The returned error is not nil if the task could not be retrieved or has failed.
+func (t *TaskTracker) checkTask() (bool, error) {
+	task, err := t.source.GetTask(t.ctx, t.taskId)
+	if err != nil {
+		// A single failed poll, e.g. while the SDDC Manager reverse proxy is restarting,
+		// must not abort waiting for a task that may run for hours
+		var pollErr *TaskPollError
+		if errors.As(err, &pollErr) && pollErr.Transient && t.ctx.Err() == nil && t.failedPolls < maxFailedPolls {
+			t.failedPolls++
+			tflog.Warn(t.ctx, fmt.Sprintf("Failed to retrieve task with ID = %s (attempt %d of %d): %s",
+				t.taskId, t.failedPolls, maxFailedPolls, err))
+			return false, nil
+		}
+		return true, err
+	}
+	t.failedPolls = 0
+	trace.SpanFromContext(t.ctx).SetAttributes(attribute.String("vcf.task.name", task.Name))
+
+	t.logTask(*task)
+	t.trackSubtask(*task)
 
-	return GetResponseAs[vcf.Task](res)
+	switch task.State {
+	case TaskStatePending, TaskStateRunning:
+		return false, nil
+	case TaskStateFailed:
+		errorMsg := fmt.Sprintf("Task with ID = %s , %s is in state %s", task.Id, describeTask(*task), task.Status)
+		tflog.Error(t.ctx, errorMsg)
+		t.logErrors(task.Errors)
+
+		// The error of the task carries the remediation and the reference token for support
+		if err := task.firstError(); err != nil {
+			return true, fmt.Errorf("%s: %w", errorMsg, err)
+		}
+		return true, errors.New(errorMsg)
+	default:
+		tflog.Info(t.ctx, fmt.Sprintf("Task with ID = %s , %s is in state %s", task.Id, describeTask(*task), task.Status))
+		return true, nil
+	}
 }
 
-func (t *TaskTracker) logTask(task vcf.Task) {
-	if task.SubTasks == nil {
-		messagePack := task.LocalizableDescriptionPack
-		if messagePack != nil && messagePack.Message != nil && task.Status != nil &&
-			t.shouldLog(*messagePack.Message, *task.Status) {
-			t.log(*messagePack.Message, *task.Status)
+func describeTask(task TrackedTask) string {
+	if task.Type == "" {
+		return fmt.Sprintf("Name: %q", task.Name)
+	}
+	return fmt.Sprintf("Name: %q Type: %q", task.Name, task.Type)
+}
+
+func (t *TaskTracker) logTask(task TrackedTask) {
+	if task.Subtasks == nil {
+		if task.Message != "" && t.shouldLog(task.Message, task.State) {
+			t.log(task.Message, task.Status)
 		}
-	} else if task.SubTasks != nil {
-		for _, subtask := range *task.SubTasks {
-			if t.shouldLog(*subtask.Description, *subtask.Status) {
-				t.log(*subtask.Description, *subtask.Status)
-				if subtask.Errors != nil {
-					t.logErrors(*subtask.Errors)
-				}
-			}
+		return
+	}
+	for _, subtask := range task.Subtasks {
+		if t.shouldLog(subtask.Description, subtask.State) {
+			t.log(subtask.Description, subtask.Status)
+			t.logErrors(subtask.Errors)
+		}
+	}
+}
+
+// trackSubtask remembers the most recent subtask that has started, so that it can be reported
+// if waiting for the task is interrupted.
+func (t *TaskTracker) trackSubtask(task TrackedTask) {
+	for _, subtask := range task.Subtasks {
+		if subtask.Description == "" || subtask.State == TaskStatePending {
+			continue
 		}
+		t.lastSubtask = subtask.Description
 	}
 }
 
-func (t *TaskTracker) shouldLog(message, status string) bool {
-	running := t.statusEqual(status, statusInProgressUppercase) ||
-		t.statusEqual(status, statusInProgress) ||
-		t.statusEqual(status, statusPending) ||
-		t.statusEqual(status, statusNotApplicable)
-	val, ok := t.completedTasks[message]
-	return !running && (!val || !ok)
+func (t *TaskTracker) shouldLog(message string, state TaskState) bool {
+	return state.finished() && !t.completedTasks[message]
 }
 
 func (t *TaskTracker) log(message, status string) {
 	tflog.Info(t.ctx, fmt.Sprintf("[%s] %s", status, message))
+	trace.SpanFromContext(t.ctx).AddEvent(message, trace.WithAttributes(attribute.String("vcf.task.status", status)))
 	t.completedTasks[message] = true
 }
 
@@ -131,6 +364,147 @@ func (t *TaskTracker) logErrors(errors []vcf.Error) {
 	}
 }
 
-func (t *TaskTracker) statusEqual(a, b string) bool {
-	return strings.EqualFold(a, b)
+// getTaskResponse parses a response of a TaskSource. Error responses are returned as a
+// *TaskPollError, which is transient if the status code suggests the request may succeed later.
+func getTaskResponse[T interface{}](ctx context.Context, res Response, taskId string) (*T, error) {
+	if res.StatusCode() < 200 || res.StatusCode() >= 300 {
+		err := fmt.Errorf("failed to retrieve task with ID %q: %d %s", taskId, res.StatusCode(), http.StatusText(res.StatusCode()))
+		if vcfErr := GetError(res.GetBody()); vcfErr != nil && vcfErr.Message != nil {
+			LogError(vcfErr, ctx)
+			err = NewApiError(vcfErr)
+		}
+		return nil, &TaskPollError{Err: err, Transient: isTransientStatusCode(res.StatusCode())}
+	}
+
+	task, _ := GetResponseAs[T](res)
+	if task == nil {
+		return nil, fmt.Errorf("failed to parse task with ID %q", taskId)
+	}
+	return task, nil
+}
+
+// sddcManagerTaskSource retrieves tasks from SDDC Manager.
+type sddcManagerTaskSource struct {
+	client *vcf.ClientWithResponses
+}
+
+func (s *sddcManagerTaskSource) GetTask(ctx context.Context, taskId string) (*TrackedTask, error) {
+	res, err := s.client.GetTaskWithResponse(ctx, taskId)
+	if err != nil {
+		return nil, &TaskPollError{Err: err, Transient: true}
+	}
+	task, err := getTaskResponse[vcf.Task](ctx, res, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &TrackedTask{
+		Id:     stringValue(task.Id),
+		Name:   stringValue(task.Name),
+		Type:   stringValue(task.Type),
+		Status: stringValue(task.Status),
+		State:  sddcManagerTaskState(stringValue(task.Status)),
+	}
+	if task.Errors != nil {
+		tracked.Errors = *task.Errors
+	}
+	if task.SubTasks == nil {
+		if pack := task.LocalizableDescriptionPack; pack != nil {
+			tracked.Message = stringValue(pack.Message)
+		}
+		return tracked, nil
+	}
+	tracked.Subtasks = make([]TrackedSubtask, 0, len(*task.SubTasks))
+	for _, subtask := range *task.SubTasks {
+		trackedSubtask := TrackedSubtask{
+			Description: stringValue(subtask.Description),
+			Status:      stringValue(subtask.Status),
+			State:       sddcManagerSubtaskState(stringValue(subtask.Status)),
+		}
+		if subtask.Errors != nil {
+			trackedSubtask.Errors = *subtask.Errors
+		}
+		tracked.Subtasks = append(tracked.Subtasks, trackedSubtask)
+	}
+	return tracked, nil
+}
+
+func (s *sddcManagerTaskSource) CancelTask(ctx context.Context, taskId string) error {
+	res, err := s.client.CancelTaskWithResponse(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() < 200 || res.StatusCode() >= 300 {
+		if vcfErr := GetError(res.Body); vcfErr != nil && vcfErr.Message != nil {
+			return NewApiError(vcfErr)
+		}
+		return errors.New(res.Status())
+	}
+	return nil
+}
+
+func (s *sddcManagerTaskSource) DescribeRunningTask(taskId string) string {
+	return fmt.Sprintf("The task is still running on SDDC Manager, track its progress in the SDDC Manager UI "+
+		"or with GET /v1/tasks/%s", taskId)
+}
+
+// hostCommissionValidationSource retrieves validations of host commission specs from SDDC
+// Manager. Every validation check is reported as a subtask.
+type hostCommissionValidationSource struct {
+	client *vcf.ClientWithResponses
+}
+
+func (s *hostCommissionValidationSource) GetTask(ctx context.Context, taskId string) (*TrackedTask, error) {
+	res, err := s.client.GetHostCommissionValidationByIDWithResponse(ctx, taskId)
+	if err != nil {
+		return nil, &TaskPollError{Err: err, Transient: true}
+	}
+	validation, err := getTaskResponse[vcf.Validation](ctx, res, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return trackValidation(*validation, "Host commission validation"), nil
+}
+
+func (s *hostCommissionValidationSource) DescribeRunningTask(taskId string) string {
+	return fmt.Sprintf("The validation is still running on SDDC Manager, track its progress with "+
+		"GET /v1/hosts/validations/%s", taskId)
+}
+
+// sddcManagerSubtaskState classifies the status of a subtask. A NOT_APPLICABLE subtask has not
+// been reached yet, so it is not logged as completed.
+func sddcManagerSubtaskState(status string) TaskState {
+	if statusIn(status, statusNotApplicable) {
+		return TaskStatePending
+	}
+	return sddcManagerTaskState(status)
+}
+
+func sddcManagerTaskState(status string) TaskState {
+	switch {
+	case statusIn(status, statusInProgress, statusInProgressUppercase):
+		return TaskStateRunning
+	case statusIn(status, statusPending):
+		return TaskStatePending
+	case statusIn(status, statusFailed, statusCancelled):
+		return TaskStateFailed
+	default:
+		return TaskStateSucceeded
+	}
+}
+
+func statusIn(status string, values ...string) bool {
+	for _, value := range values {
+		if strings.EqualFold(status, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
 }
@@ -0,0 +1,228 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+const testPollingInterval = 10 * time.Millisecond
+
+func newTestSddcManagerClient(t *testing.T, server *fake_server.Server, opts ...ClientOption) *SddcManagerClient {
+	// Keep retries of transient failures fast, do not limit the requests and send every request
+	opts = append([]ClientOption{
+		WithRetry(DefaultMaxRetries, time.Millisecond), WithRequestLimits(0, 0), WithInventoryCache(false),
+	}, opts...)
+	client := NewSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true, opts...)
+	if err := client.Connect(); err != nil {
+		t.Fatal("failed to connect to the fake SDDC Manager", err)
+	}
+	return client
+}
+
+func TestWaitForTask_success(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	taskId := server.AddTask(vcf.Task{},
+		fake_server.TaskStatusInProgress, fake_server.TaskStatusInProgress, fake_server.TaskStatusSuccessful)
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, taskId, testPollingInterval)
+	if err := tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+
+	if polls := server.RequestCount(http.MethodGet, "/v1/tasks/"+taskId); polls != 3 {
+		t.Fatalf("expected the task to be polled 3 times, got %d", polls)
+	}
+}
+
+func TestWaitForTask_clientPollingInterval(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithTaskPollingInterval(testPollingInterval))
+	taskId := server.AddTask(vcf.Task{}, fake_server.TaskStatusInProgress, fake_server.TaskStatusSuccessful)
+
+	// The default polling interval is long, the tracker must poll at the interval of the client
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.NewTaskTracker(ctx, taskId).WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
+
+func TestWaitForTask_notApplicableTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	taskId := server.AddTask(vcf.Task{}, "NOT_APPLICABLE")
+
+	// A task which is not applicable has nothing left to do, it must not be polled until the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	tracker := NewTaskTrackerWithCustomPollingInterval(ctx, client.ApiClient, taskId, testPollingInterval)
+	if err := tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if polls := server.RequestCount(http.MethodGet, "/v1/tasks/"+taskId); polls != 1 {
+		t.Fatalf("expected the task to be polled once, got %d", polls)
+	}
+}
+
+func TestSddcManagerSubtaskState(t *testing.T) {
+	if state := sddcManagerSubtaskState("NOT_APPLICABLE"); state != TaskStatePending {
+		t.Fatalf("expected a NOT_APPLICABLE subtask to be pending, got %d", state)
+	}
+	if state := sddcManagerSubtaskState("SUCCESSFUL"); state != TaskStateSucceeded {
+		t.Fatalf("expected a SUCCESSFUL subtask to have succeeded, got %d", state)
+	}
+}
+
+func TestWaitForTask_failedTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	taskId := server.AddTask(vcf.Task{
+		SubTasks: &[]vcf.SubTask{{
+			Description: ptr("Deploy vCenter Server"),
+			Status:      ptr(fake_server.TaskStatusFailed),
+			Errors:      &[]vcf.Error{{Message: ptr("vCenter Server deployment failed"), ReferenceToken: ptr("3NFVOM")}},
+		}},
+	}, fake_server.TaskStatusInProgress, fake_server.TaskStatusFailed)
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, taskId, testPollingInterval)
+	err := tracker.WaitForTask()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), taskId) || !strings.Contains(err.Error(), fake_server.TaskStatusFailed) {
+		t.Fatal("unexpected error", err)
+	}
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.ReferenceToken() != "3NFVOM" {
+		t.Fatal("expected the error of the failed subtask", err)
+	}
+}
+
+func TestWaitForTask_taskNotFound(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, "missing", testPollingInterval)
+	err := tracker.WaitForTask()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestWaitForTask_transientPollFailure(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(0, 0))
+	taskId := server.AddTask(vcf.Task{}, fake_server.TaskStatusSuccessful)
+	server.FailRequests(http.MethodGet, "/v1/tasks/"+taskId, 2, http.StatusServiceUnavailable, vcf.Error{})
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, taskId, testPollingInterval)
+	if err := tracker.WaitForTask(); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
+
+func TestWaitForTask_persistentPollFailure(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithRetry(0, 0))
+	taskId := server.AddTask(vcf.Task{}, fake_server.TaskStatusSuccessful)
+	server.FailRequests(http.MethodGet, "/v1/tasks/"+taskId, maxFailedPolls+1, http.StatusBadGateway, vcf.Error{})
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, taskId, testPollingInterval)
+	if err := tracker.WaitForTask(); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func testLongRunningTask(server *fake_server.Server) string {
+	return server.AddTask(vcf.Task{
+		SubTasks: &[]vcf.SubTask{
+			{Description: ptr("Validate input"), Status: ptr(fake_server.TaskStatusSuccessful)},
+			{Description: ptr("Deploy vCenter Server"), Status: ptr(fake_server.TaskStatusInProgress)},
+			{Description: ptr("Deploy NSX Manager"), Status: ptr("Pending")},
+		},
+	}, fake_server.TaskStatusInProgress)
+}
+
+func TestWaitForTask_interrupted(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	taskId := testLongRunningTask(server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*testPollingInterval)
+	defer cancel()
+
+	err := client.NewTaskTrackerWithCustomPollingInterval(ctx, taskId, testPollingInterval).WaitForTask()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected the error to wrap the cause of the interruption", err)
+	}
+	if !strings.Contains(err.Error(), taskId) || !strings.Contains(err.Error(), "Deploy vCenter Server") ||
+		!strings.Contains(err.Error(), "still running") {
+		t.Fatal("unexpected error", err)
+	}
+	var interrupted *TaskInterruptedError
+	if !errors.As(err, &interrupted) || interrupted.TaskId != taskId {
+		t.Fatal("expected a TaskInterruptedError", err)
+	}
+	if count := server.RequestCount(http.MethodDelete, "/v1/tasks/"+taskId); count != 0 {
+		t.Fatal("the task must not be cancelled by default")
+	}
+}
+
+func TestWaitForTask_interruptedCancelsTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithCancelTasksOnInterrupt(true))
+	taskId := testLongRunningTask(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(5*testPollingInterval, cancel)
+
+	err := client.NewTaskTrackerWithCustomPollingInterval(ctx, taskId, testPollingInterval).WaitForTask()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "has been cancelled") {
+		t.Fatal("unexpected error", err)
+	}
+	if task, _ := server.Task(taskId); *task.Status != fake_server.TaskStatusCancelled {
+		t.Fatal("expected the task to be cancelled, got", *task.Status)
+	}
+}
+
+func TestWaitForTask_interruptedCompletedTask(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithCancelTasksOnInterrupt(true))
+	taskId := server.AddTask(vcf.Task{}, fake_server.TaskStatusSuccessful)
+	server.FailRequests(http.MethodDelete, "/v1/tasks/"+taskId, 1, http.StatusConflict,
+		vcf.Error{Message: ptr("Task has already completed")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.NewTaskTracker(ctx, taskId).WaitForTask()
+	if err == nil || !strings.Contains(err.Error(), "Task has already completed") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,43 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// timeoutTransport is a http.RoundTripper which limits how long a request takes, from sending
+// it until its response body is closed. It is the innermost transport, so every attempt of a
+// retried request has the full timeout.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+// newTimeoutTransport returns a timeoutTransport, or next itself if the timeout is 0.
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) http.RoundTripper {
+	if timeout <= 0 {
+		return next
+	}
+	return &timeoutTransport{next: next, timeout: timeout}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if res.Body == nil || res.Body == http.NoBody {
+		cancel()
+		return res, nil
+	}
+	// The deadline also applies to reading the body, it is released once the body is closed
+	res.Body = &releasingBody{ReadCloser: res.Body, release: cancel}
+	return res, nil
+}
@@ -0,0 +1,77 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newStalledServer starts a server which stalls before the response or, if headers is true,
+// after the headers of the response.
+func newStalledServer(t *testing.T, headers bool) *httptest.Server {
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if headers {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("["))
+			w.(http.Flusher).Flush()
+		}
+		<-stalled
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(stalled) })
+	return server
+}
+
+func newTimeoutTestClient(t *testing.T) *http.Client {
+	tr, err := newClientConfig(nil).newTransport(false)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	return &http.Client{Transport: newTimeoutTransport(tr, 100*time.Millisecond)}
+}
+
+func TestTimeoutTransport_stalledResponse(t *testing.T) {
+	server := newStalledServer(t, false)
+
+	res, err := newTimeoutTestClient(t).Get(server.URL)
+	if err == nil {
+		_ = res.Body.Close()
+		t.Fatal("expected the request to time out")
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatal("expected a timeout error, got", err)
+	}
+}
+
+func TestTimeoutTransport_stalledBody(t *testing.T) {
+	server := newStalledServer(t, true)
+
+	res, err := newTimeoutTestClient(t).Get(server.URL)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	// The headers have been received, reading the body must still time out
+	if _, err = io.ReadAll(res.Body); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected the body to time out, got", err)
+	}
+}
+
+func TestTimeoutTransport_disabled(t *testing.T) {
+	next := &countingTransport{}
+	if tr := newTimeoutTransport(next, 0); tr != next {
+		t.Fatal("expected a timeout of 0 to disable the limit")
+	}
+}
@@ -0,0 +1,101 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// newTlsConfig returns the TLS settings of the HTTP client.
+//
+// A pinned server thumbprint takes precedence over the certificate chain: the server is trusted
+// if and only if its certificate matches the pin. This makes it possible to connect securely to
+// an instance which still uses the self-signed certificate it was brought up with.
+func (config clientConfig) newTlsConfig(allowUnverifiedTls bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: allowUnverifiedTls}
+
+	if config.caCertificate != "" {
+		rootCAs, err := loadCaCertificate(config.caCertificate)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if config.serverThumbprint != "" {
+		thumbprint, err := parseThumbprint(config.serverThumbprint)
+		if err != nil {
+			return nil, err
+		}
+		// The default verification is replaced by the comparison of the thumbprints
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyThumbprint(state, thumbprint)
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadCaCertificate returns the system certificate pool extended with the given CA, which is
+// either PEM encoded or the path of a PEM file.
+func loadCaCertificate(caCertificate string) (*x509.CertPool, error) {
+	pem := []byte(caCertificate)
+	if !strings.Contains(caCertificate, "-----BEGIN") {
+		content, err := os.ReadFile(caCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA certificate: %w", err)
+		}
+		pem = content
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(pem) {
+		return nil, errors.New("the CA certificate does not contain any PEM encoded certificate")
+	}
+	return rootCAs, nil
+}
+
+// parseThumbprint parses a hex encoded SHA-256 thumbprint. The bytes may be separated by
+// colons, as displayed by browsers and openssl.
+func parseThumbprint(thumbprint string) ([]byte, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(thumbprint), ":", "")
+	result, err := hex.DecodeString(normalized)
+	if err != nil || len(result) != sha256.Size {
+		return nil, fmt.Errorf("invalid server thumbprint %q, expected the hex encoded SHA-256 thumbprint of the certificate", thumbprint)
+	}
+	return result, nil
+}
+
+func verifyThumbprint(state tls.ConnectionState, thumbprint []byte) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("the server did not present a certificate")
+	}
+	actual := sha256.Sum256(state.PeerCertificates[0].Raw)
+	if !bytes.Equal(actual[:], thumbprint) {
+		return fmt.Errorf("the SHA-256 thumbprint %s of the certificate of %s does not match the server thumbprint",
+			formatThumbprint(actual[:]), state.ServerName)
+	}
+	return nil
+}
+
+func formatThumbprint(thumbprint []byte) string {
+	parts := make([]string, len(thumbprint))
+	for i, b := range thumbprint {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
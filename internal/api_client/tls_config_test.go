@@ -0,0 +1,101 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+func connectSddcManager(server *fake_server.Server, opts ...ClientOption) error {
+	client := NewSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", false, opts...)
+	return client.Connect()
+}
+
+func testCaCertificate(server *fake_server.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestTlsConfig_untrustedCertificate(t *testing.T) {
+	server := fake_server.NewServer(t)
+
+	if err := connectSddcManager(server); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestTlsConfig_caCertificate(t *testing.T) {
+	server := fake_server.NewServer(t)
+
+	if err := connectSddcManager(server, WithCaCertificate(testCaCertificate(server))); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
+
+func TestTlsConfig_caCertificateFile(t *testing.T) {
+	server := fake_server.NewServer(t)
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, []byte(testCaCertificate(server)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := connectSddcManager(server, WithCaCertificate(path)); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
+
+func TestTlsConfig_invalidCaCertificate(t *testing.T) {
+	server := fake_server.NewServer(t)
+
+	if err := connectSddcManager(server, WithCaCertificate(filepath.Join(t.TempDir(), "missing.pem"))); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := connectSddcManager(server, WithCaCertificate("-----BEGIN CERTIFICATE-----")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestTlsConfig_serverThumbprint(t *testing.T) {
+	server := fake_server.NewServer(t)
+	thumbprint := sha256.Sum256(server.Certificate().Raw)
+
+	if err := connectSddcManager(server, WithServerThumbprint(hex.EncodeToString(thumbprint[:]))); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if err := connectSddcManager(server, WithServerThumbprint(formatThumbprint(thumbprint[:]))); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
+
+func TestTlsConfig_serverThumbprintMismatch(t *testing.T) {
+	server := fake_server.NewServer(t)
+	thumbprint := strings.Repeat("AB", sha256.Size)
+
+	// The pin is enforced even if unverified certificates are allowed
+	client := NewSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true,
+		WithRetry(0, 0), WithServerThumbprint(thumbprint))
+	err := client.Connect()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "does not match the server thumbprint") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestParseThumbprint(t *testing.T) {
+	for _, thumbprint := range []string{"", "AB:CD", "not a thumbprint", strings.Repeat("AB", sha256.Size+1)} {
+		if _, err := parseThumbprint(thumbprint); err == nil {
+			t.Errorf("expected an error for %q", thumbprint)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// tokenRefreshMargin is how long before its expiry an access token is renewed, so that a
+	// request never goes out with a token which expires while it is in flight.
+	tokenRefreshMargin = time.Minute
+	// defaultTokenLifetime is assumed for access tokens whose expiry cannot be determined.
+	defaultTokenLifetime = 20 * time.Minute
+	// issuedTokenLifetime is assumed for pre-issued access tokens whose expiry cannot be
+	// determined, they are used until the server rejects them.
+	issuedTokenLifetime = 100 * 365 * 24 * time.Hour
+)
+
+// tokenPair holds the tokens issued by SDDC Manager or the installer on login.
+type tokenPair struct {
+	accessToken  string
+	refreshToken string
+}
+
+// tokenManager owns the access token of a client and renews it when it is about to expire.
+//
+// It is safe for concurrent use. Renewals are serialized, so when several requests find the
+// token expired at the same time only one of them obtains a new token and the others reuse it.
+type tokenManager struct {
+	// login creates a new token pair from the credentials of the client.
+	login func(ctx context.Context) (tokenPair, error)
+	// refresh obtains a new access token with a refresh token.
+	refresh func(ctx context.Context, refreshToken string) (string, error)
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+// AccessToken returns a valid access token, renewing the current one if it is missing or about to expire.
+func (m *tokenManager) AccessToken(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.accessToken != "" && time.Now().Before(m.expiresAt.Add(-tokenRefreshMargin)) {
+		return m.accessToken, nil
+	}
+	if err := m.renew(ctx); err != nil {
+		// A token which cannot be renewed is still used until it actually expires
+		if m.accessToken != "" && time.Now().Before(m.expiresAt) {
+			return m.accessToken, nil
+		}
+		return "", err
+	}
+	return m.accessToken, nil
+}
+
+// invalidate discards the given access token after it has been rejected by the server. It does
+// nothing if the token has already been replaced by a concurrent request.
+func (m *tokenManager) invalidate(accessToken string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.accessToken == accessToken {
+		m.accessToken = ""
+	}
+}
+
+// renew obtains a new access token, preferably with the refresh token and otherwise by logging
+// in again. Must be called with the lock held.
+func (m *tokenManager) renew(ctx context.Context) error {
+	if m.refreshToken != "" {
+		accessToken, err := m.refresh(ctx, m.refreshToken)
+		if err == nil {
+			m.setAccessToken(accessToken)
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("failed to refresh the access token, logging in again: %s", err))
+	}
+
+	tokens, err := m.login(ctx)
+	if err != nil {
+		return err
+	}
+	m.refreshToken = tokens.refreshToken
+	m.setAccessToken(tokens.accessToken)
+	return nil
+}
+
+// setIssuedAccessToken sets an access token which was issued beforehand and is used as is. Its
+// expiry is taken from the token, a token whose expiry is unknown is used until it is rejected.
+func (m *tokenManager) setIssuedAccessToken(accessToken string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.accessToken = accessToken
+	if expiresAt, ok := jwtExpiry(accessToken); ok {
+		m.expiresAt = expiresAt
+	} else {
+		m.expiresAt = time.Now().Add(issuedTokenLifetime)
+	}
+}
+
+func (m *tokenManager) setAccessToken(accessToken string) {
+	m.accessToken = accessToken
+	if expiresAt, ok := jwtExpiry(accessToken); ok {
+		m.expiresAt = expiresAt
+	} else {
+		m.expiresAt = time.Now().Add(defaultTokenLifetime)
+	}
+}
+
+// jwtExpiry returns the time of the "exp" claim of a JWT. The signature is not verified, the
+// token is only inspected to find out when it has to be renewed.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp *int64 `json:"exp"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*claims.Exp, 0), true
+}
+
+// authTransport is a http.RoundTripper which authenticates requests with the access token of a
+// tokenManager. A request rejected with 401 Unauthorized is sent once more with a new token.
+type authTransport struct {
+	next   http.RoundTripper
+	tokens *tokenManager
+}
+
+func newAuthTransport(next http.RoundTripper, tokens *tokenManager) *authTransport {
+	return &authTransport{
+		next:   next,
+		tokens: tokens,
+	}
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isTokenRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	accessToken, err := t.tokens.AccessToken(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	res, err := t.next.RoundTrip(withAccessToken(req, accessToken))
+	if err != nil || res.StatusCode != http.StatusUnauthorized || !isRewindable(req) {
+		return res, err
+	}
+
+	// The token was revoked or has expired earlier than announced, e.g. because the
+	// clock of the server is ahead. The request was rejected, so it is safe to send it again.
+	tflog.Debug(req.Context(), fmt.Sprintf("%s %s was rejected with %s, renewing the access token",
+		req.Method, req.URL.Path, res.Status))
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+
+	t.tokens.invalidate(accessToken)
+	if accessToken, err = t.tokens.AccessToken(req.Context()); err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	if req, err = rewindRequest(req); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(withAccessToken(req, accessToken))
+}
+
+// isTokenRequest reports whether the request targets the endpoints which issue access tokens,
+// which are the only ones that do not require authentication.
+func isTokenRequest(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.Path, "/v1/tokens")
+}
+
+func withAccessToken(req *http.Request, accessToken string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	return clone
+}
@@ -0,0 +1,183 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+)
+
+const refreshTokenPath = "/v1/tokens/access-token/refresh"
+
+func getDomains(t *testing.T, client *SddcManagerClient) {
+	res, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Error("received an unexpected error", err)
+		return
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Error("unexpected status code", res.StatusCode())
+	}
+}
+
+func TestTokenManager_refreshesExpiringToken(t *testing.T) {
+	server := fake_server.NewServer(t)
+	// Shorter than tokenRefreshMargin, every token is renewed before it is used
+	server.SetAccessTokenLifetime(30 * time.Second)
+	client := newTestSddcManagerClient(t, server)
+
+	getDomains(t, client)
+	getDomains(t, client)
+
+	if count := server.RequestCount(http.MethodPost, "/v1/tokens"); count != 1 {
+		t.Fatalf("expected a single login, got %d", count)
+	}
+	if count := server.RequestCount(http.MethodPatch, refreshTokenPath); count != 2 {
+		t.Fatalf("expected 2 token refreshes, got %d", count)
+	}
+}
+
+func TestTokenManager_reusesValidToken(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+
+	getDomains(t, client)
+	getDomains(t, client)
+
+	if count := server.RequestCount(http.MethodPatch, refreshTokenPath); count != 0 {
+		t.Fatalf("expected no token refresh, got %d", count)
+	}
+}
+
+func TestTokenManager_unauthorizedRequest(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.ExpireAccessTokens()
+
+	getDomains(t, client)
+
+	if count := server.RequestCount(http.MethodGet, "/v1/domains"); count != 2 {
+		t.Fatalf("expected the request to be sent twice, got %d", count)
+	}
+	if count := server.RequestCount(http.MethodPatch, refreshTokenPath); count != 1 {
+		t.Fatalf("expected a single token refresh, got %d", count)
+	}
+}
+
+func TestTokenManager_expiredRefreshToken(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.ExpireAccessTokens()
+	server.ExpireRefreshTokens()
+
+	getDomains(t, client)
+
+	if count := server.RequestCount(http.MethodPost, "/v1/tokens"); count != 2 {
+		t.Fatalf("expected to log in again, got %d logins", count)
+	}
+}
+
+func TestTokenManager_concurrentRequests(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	server.ExpireAccessTokens()
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			getDomains(t, client)
+		}()
+	}
+	wg.Wait()
+
+	if count := server.RequestCount(http.MethodPatch, refreshTokenPath); count != 1 {
+		t.Fatalf("expected a single token refresh, got %d", count)
+	}
+}
+
+func TestInstallerClient_unauthorizedRequest(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := NewInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true)
+	if err := client.Connect(); err != nil {
+		t.Fatal("failed to connect to the fake installer", err)
+	}
+	server.ExpireAccessTokens()
+
+	res, err := client.ApiClient.GetSddcTasksWithResponse(context.Background())
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatal("unexpected status code", res.StatusCode())
+	}
+}
+
+func TestTokenManager_apiKey(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithApiKey(fake_server.ApiKey))
+	server.ExpireAccessTokens()
+	server.ExpireRefreshTokens()
+
+	getDomains(t, client)
+
+	// The client logs in again with the API key once its tokens have expired
+	if count := server.RequestCount(http.MethodPost, "/v1/tokens"); count != 2 {
+		t.Fatalf("expected to log in again, got %d logins", count)
+	}
+}
+
+func TestTokenManager_accessToken(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithAccessToken(server.IssueAccessToken()))
+
+	getDomains(t, client)
+
+	if count := server.RequestCount(http.MethodPost, "/v1/tokens"); count != 0 {
+		t.Fatalf("expected the client not to log in, got %d logins", count)
+	}
+}
+
+func TestTokenManager_expiringAccessToken(t *testing.T) {
+	server := fake_server.NewServer(t)
+	// Within tokenRefreshMargin, the token cannot be renewed but is still valid
+	server.SetAccessTokenLifetime(30 * time.Second)
+	client := newTestSddcManagerClient(t, server, WithAccessToken(server.IssueAccessToken()))
+
+	getDomains(t, client)
+}
+
+func TestTokenManager_revokedAccessToken(t *testing.T) {
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server, WithAccessToken(server.IssueAccessToken()))
+	server.ExpireAccessTokens()
+
+	_, err := client.ApiClient.GetDomainsWithResponse(context.Background(), nil)
+	if !errors.Is(err, errAccessTokenExpired) {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestJwtExpiry(t *testing.T) {
+	// {"alg":"none"}.{"sub":"administrator@vsphere.local","exp":1735689600}
+	token := "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhZG1pbmlzdHJhdG9yQHZzcGhlcmUubG9jYWwiLCJleHAiOjE3MzU2ODk2MDB9."
+	expiry, ok := jwtExpiry(token)
+	if !ok || !expiry.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected expiry %s, %t", expiry, ok)
+	}
+
+	for _, token := range []string{"", "opaque-token", "a.!!!.c", "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhZG1pbiJ9."} {
+		if _, ok := jwtExpiry(token); ok {
+			t.Errorf("expected no expiry for %q", token)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
+)
+
+// tracingTransport is a http.RoundTripper which records a span for every request, as a child
+// of the span of the resource operation or task wait which sends it.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+func newTracingTransport(next http.RoundTripper) *tracingTransport {
+	return &tracingTransport{next: next}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.StartSpan(req.Context(), fmt.Sprintf("%s %s", req.Method, req.URL.Path),
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.full", req.URL.String()),
+		attribute.String("server.address", req.URL.Hostname()),
+	)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		tracing.EndSpan(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	if res.StatusCode >= http.StatusBadRequest {
+		tracing.EndSpan(span, fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, res.Status))
+		return res, nil
+	}
+	tracing.EndSpan(span, nil)
+	return res, nil
+}
@@ -0,0 +1,77 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func findSpan(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestWaitForTask_tracing(t *testing.T) {
+	recorder := recordSpans(t)
+	server := fake_server.NewServer(t)
+	client := newTestSddcManagerClient(t, server)
+	taskId := server.AddTask(vcf.Task{
+		SubTasks: &[]vcf.SubTask{{
+			Description: ptr("Deploy vCenter Server"),
+			Status:      ptr(fake_server.TaskStatusSuccessful),
+		}},
+	}, fake_server.TaskStatusInProgress, fake_server.TaskStatusFailed)
+
+	tracker := NewTaskTrackerWithCustomPollingInterval(context.Background(), client.ApiClient, taskId, testPollingInterval)
+	if err := tracker.WaitForTask(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	spans := recorder.Ended()
+	wait := findSpan(spans, "WaitForTask")
+	if wait == nil {
+		t.Fatal("expected a span for waiting for the task", spans)
+	}
+	if wait.Status().Code != codes.Error {
+		t.Fatal("expected the span of the failed task to have an error status")
+	}
+	hasTaskId := false
+	for _, attr := range wait.Attributes() {
+		hasTaskId = hasTaskId || (attr.Key == tracing.AttributeTaskId && attr.Value.AsString() == taskId)
+	}
+	if !hasTaskId {
+		t.Fatal("expected the task ID to be recorded", wait.Attributes())
+	}
+	if events := wait.Events(); len(events) == 0 || events[0].Name != "Deploy vCenter Server" {
+		t.Fatal("expected an event for the subtask", events)
+	}
+
+	poll := findSpan(spans, "GET /v1/tasks/"+taskId)
+	if poll == nil || poll.Parent().SpanID() != wait.SpanContext().SpanID() {
+		t.Fatal("expected the polls to be children of the span of the task", poll)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"context"
 	md52 "crypto/md5"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -25,12 +24,12 @@ func ValidateResourceCertificates(ctx context.Context, client *vcf.ClientWithRes
 	domainId string, resourceCertificateSpecs []vcf.ResourceCertificateSpec) diag.Diagnostics {
 	okResponse, err := client.ValidateResourceCertificatesWithResponse(ctx, domainId, resourceCertificateSpecs)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.CertificateValidationTask](okResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 	if validationutils.HaveCertificateValidationsFailed(task) {
 		return validationutils.ConvertCertificateValidationsResultToDiag(task)
@@ -45,7 +44,7 @@ func ValidateResourceCertificates(ctx context.Context, client *vcf.ClientWithRes
 			task, vcfErr = api_client.GetResponseAs[vcf.CertificateValidationTask](getValidationResponse)
 			if vcfErr != nil {
 				api_client.LogError(vcfErr, ctx)
-				return diag.FromErr(errors.New(*vcfErr.Message))
+				return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 			}
 			if validationutils.HasCertificateValidationFinished(task) {
 				break
@@ -81,36 +80,30 @@ func GenerateCertificateForResource(ctx context.Context, client *api_client.Sddc
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return errors.New(*vcfErr.Message)
+		return api_client.NewApiError(vcfErr)
 	}
-	return api_client.NewTaskTracker(ctx, client.ApiClient, *task.Id).WaitForTask()
+	return client.NewTaskTracker(ctx, *task.Id).WaitForTask()
 }
 
 func ReadCertificate(ctx context.Context, client *vcf.ClientWithResponses,
 	domainId, resourceFqdn string) (*vcf.Certificate, error) {
 
-	certificatesResponse, err := client.GetCertificatesByDomainWithResponse(ctx, domainId)
+	cert, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		res, err := client.GetCertificatesByDomainWithResponse(ctx, domainId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate by domain: %w", err)
+		}
+		return res, nil
+	}, func(cert vcf.Certificate) bool {
+		return cert.IssuedTo != nil && *cert.IssuedTo == resourceFqdn
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get certificate by domain: %w", err)
+		return nil, err
 	}
-
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfCertificate](certificatesResponse)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
-	}
-	// Check if any certificates are found
-	if page == nil || len(*page.Elements) == 0 {
-		return nil, fmt.Errorf("no certificates found for domain ID %s", domainId)
-	}
-
-	allCertsForDomain := page.Elements
-	for _, cert := range *allCertsForDomain {
-		if cert.IssuedTo != nil && *cert.IssuedTo == resourceFqdn {
-			return &cert, nil
-		}
+	if cert == nil {
+		return nil, fmt.Errorf("no certificate found for resource FQDN %s in domain ID %s", resourceFqdn, domainId)
 	}
-	return nil, fmt.Errorf("no certificate found for resource FQDN %s in domain ID %s", resourceFqdn, domainId)
+	return cert, nil
 }
 
 func FlattenCertificateWithSubject(cert *vcf.Certificate) map[string]interface{} {
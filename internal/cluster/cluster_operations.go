@@ -6,7 +6,6 @@ package cluster
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sort"
 
@@ -32,9 +31,9 @@ func CreateClusterUpdateSpec(data *schema.ResourceData, markForDeletion bool) (*
 	}
 
 	if data.HasChange("host") {
-		oldHostsValue, newHostsValue := data.GetChange("host")
+		oldHostsValue, _ := data.GetChange("host")
 		return SetExpansionOrContractionSpec(result,
-			oldHostsValue.([]interface{}), newHostsValue.([]interface{}))
+			oldHostsValue.([]interface{}), utils.GetWithWriteOnly(data, "host").([]interface{}))
 	}
 
 	if data.HasChange("vsan_stretch_configuration") {
@@ -88,7 +87,8 @@ func SetExpansionOrContractionSpec(updateSpec *vcf.ClusterUpdateSpec,
 // SetStretchOrUnstretchSpec sets ClusterStretchSpec or ClusterUnstretchSpec to a provided
 // ClusterUpdateSpec depending on weather a witness host is being added or removed.
 func SetStretchOrUnstretchSpec(updateSpec *vcf.ClusterUpdateSpec, data *schema.ResourceData) (*vcf.ClusterUpdateSpec, error) {
-	configOld, configNew := data.GetChange("vsan_stretch_configuration")
+	configOld, _ := data.GetChange("vsan_stretch_configuration")
+	configNew := utils.GetWithWriteOnly(data, "vsan_stretch_configuration")
 
 	if len(configOld.([]interface{})) == len(configNew.([]interface{})) {
 		return nil, fmt.Errorf("updating the stretch configuration is not supported")
@@ -150,7 +150,7 @@ func ValidateClusterUpdateOperation(ctx context.Context, clusterId string,
 	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	if validationUtils.HasValidationFailed(validationResult) {
@@ -167,14 +167,14 @@ func TryConvertResourceDataToClusterSpec(data *schema.ResourceData) (*vcf.Cluste
 	intermediaryMap["high_availability_enabled"] = data.Get("high_availability_enabled")
 	intermediaryMap["geneve_vlan_id"] = data.Get("geneve_vlan_id")
 	intermediaryMap["ip_address_pool"] = data.Get("ip_address_pool")
-	intermediaryMap["host"] = data.Get("host")
+	intermediaryMap["host"] = utils.GetWithWriteOnly(data, "host")
 	intermediaryMap["vds"] = data.Get("vds")
 	intermediaryMap["vsan_datastore"] = data.Get("vsan_datastore")
 	intermediaryMap["vmfs_datastore"] = data.Get("vmfs_datastore")
 	intermediaryMap["vsan_remote_datastore_cluster"] = data.Get("vsan_remote_datastore_cluster")
 	intermediaryMap["nfs_datastores"] = data.Get("nfs_datastores")
 	intermediaryMap["vvol_datastores"] = data.Get("vvol_datastores")
-	intermediaryMap["vsan_stretch_configuration"] = data.Get("vsan_stretch_configuration")
+	intermediaryMap["vsan_stretch_configuration"] = utils.GetWithWriteOnly(data, "vsan_stretch_configuration")
 	return TryConvertToClusterSpec(intermediaryMap)
 }
 
@@ -396,7 +396,7 @@ func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *vc
 	clusterObj, vcfErr := api_client.GetResponseAs[vcf.Cluster](clusterRes)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return nil, api_client.NewApiError(vcfErr)
 	}
 
 	data.SetId(*clusterObj.Id)
@@ -422,24 +422,25 @@ func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *vc
 
 	// get all domains and find our cluster to set the "domain_id" attribute, because
 	// cluster API doesn't provide parent domain ID.
-	getDomainsParams := &vcf.GetDomainsParams{}
-	domainsRes, err := apiClient.GetDomainsWithResponse(ctx, getDomainsParams)
-	if err != nil {
-		return nil, err
-	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfDomain](domainsRes)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
-	}
-	allDomains := *page.Elements
-	for _, domain := range allDomains {
+	domain, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return apiClient.GetDomainsWithResponse(ctx, &vcf.GetDomainsParams{})
+	}, func(domain vcf.Domain) bool {
+		if domain.Clusters == nil {
+			return false
+		}
 		for _, clusterRef := range *domain.Clusters {
 			if clusterRef.Id == clusterId {
-				_ = data.Set("domain_id", domain.Id)
-				_ = data.Set("domain_name", domain.Name)
+				return true
 			}
 		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if domain != nil {
+		_ = data.Set("domain_id", domain.Id)
+		_ = data.Set("domain_name", domain.Name)
 	}
 
 	return []*schema.ResourceData{data}, nil
@@ -463,7 +464,7 @@ func getFlattenedHostSpecsForRefs(ctx context.Context, hostRefs []vcf.HostRefere
 		hostObj, vcfErr := api_client.GetResponseAs[vcf.Host](res)
 		if vcfErr != nil {
 			api_client.LogError(vcfErr, ctx)
-			return nil, errors.New(*vcfErr.Message)
+			return nil, api_client.NewApiError(vcfErr)
 		}
 		flattenedHostSpecs = append(flattenedHostSpecs, *FlattenHost(*hostObj))
 	}
@@ -479,7 +480,7 @@ func getFlattenedVdsSpecsForCluster(ctx context.Context, clusterID string, clien
 	vdses, vcfErr := api_client.GetResponseAs[[]vcf.Vds](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return nil, api_client.NewApiError(vcfErr)
 	}
 
 	result := make([]map[string]interface{}, len(*vdses))
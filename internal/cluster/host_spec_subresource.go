@@ -57,6 +57,9 @@ func HostSpecSchema() *schema.Resource {
 				Description:  "Password to authenticate to the ESXi host",
 				ValidateFunc: validation.NoZeroValues,
 			},
+			// The password is only used when the host is added, so it has no version
+			"password_wo":         utils.WriteOnlySchema("password", "Password to authenticate to the ESXi host", validation.NoZeroValues),
+			"password_wo_version": utils.WriteOnlyVersionSchema("password", false),
 			"serial_number": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -149,8 +152,8 @@ func TryConvertToHostSpec(object map[string]interface{}) (*vcf.HostSpec, error)
 	if userName, ok := object["username"]; ok && !validationutils.IsEmpty(userName) {
 		result.Username = utils.ToStringPointer(userName)
 	}
-	if password, ok := object["password"]; ok && !validationutils.IsEmpty(password) {
-		result.Password = utils.ToStringPointer(password)
+	if password := utils.GetBlockSecret(object, "password"); password != "" {
+		result.Password = &password
 	}
 	if serialNumber, ok := object["serial_number"]; ok && !validationutils.IsEmpty(serialNumber) {
 		result.SerialNumber = utils.ToStringPointer(serialNumber)
@@ -25,6 +25,31 @@ const (
 	// to be used in acceptance tests.
 	VcfTestAllowUnverifiedTls = "VCF_TEST_ALLOW_UNVERIFIED_TLS"
 
+	// VcfCaCertificate the CA certificate, PEM encoded or the path of a PEM file, trusted for SDDC Manager.
+	VcfCaCertificate = "VCF_CA_CERTIFICATE"
+	// VcfServerThumbprint the SHA-256 thumbprint of the certificate of SDDC Manager.
+	VcfServerThumbprint = "VCF_SERVER_THUMBPRINT"
+	// InstallerCaCertificate the CA certificate, PEM encoded or the path of a PEM file, trusted for the installer.
+	InstallerCaCertificate = "INSTALLER_CA_CERTIFICATE"
+	// InstallerServerThumbprint the SHA-256 thumbprint of the certificate of the installer.
+	InstallerServerThumbprint = "INSTALLER_SERVER_THUMBPRINT"
+	// VcfApiKey the API key of a service account, used to log in to SDDC Manager.
+	VcfApiKey = "VCF_API_KEY"
+	// VcfAccessToken an access token issued by SDDC Manager, used instead of logging in.
+	VcfAccessToken = "VCF_ACCESS_TOKEN"
+	// VcfCredentialProcess a command which prints the credentials of the provider as JSON.
+	VcfCredentialProcess = "VCF_CREDENTIAL_PROCESS"
+	// VcfCredentialsFile the path of the credentials file with named profiles.
+	VcfCredentialsFile = "VCF_CREDENTIALS_FILE"
+	// VcfProfile the profile of the credentials file to use.
+	VcfProfile = "VCF_PROFILE"
+	// VcfAuditLogPath the path of the audit log of the requests sent by the provider.
+	VcfAuditLogPath = "VCF_AUDIT_LOG_PATH"
+	// VcfTraceExporter enables tracing, "otlp" to export the spans to a collector or "file".
+	VcfTraceExporter = "VCF_TRACE_EXPORTER"
+	// VcfTraceFile the path of the file the spans are written to with the "file" exporter.
+	VcfTraceFile = "VCF_TRACE_FILE"
+
 	// VcfTestHost1Fqdn the FQDN of the first ESXi host, that has not been commissioned
 	// with the SDDC Manager.
 	VcfTestHost1Fqdn = "VCF_TEST_HOST1_FQDN"
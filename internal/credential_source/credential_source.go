@@ -0,0 +1,293 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package credential_source retrieves the credentials of the provider and other secrets from
+// outside the Terraform configuration: from an external command, similar to the
+// credential_process of the AWS CLI, or from a profile of a credentials file.
+package credential_source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultProfile is the profile read from the credentials file if none is set.
+	DefaultProfile = "default"
+
+	// credentialProcessKey is the key of a profile which sets a command to run.
+	credentialProcessKey = "credential_process"
+	// secretKeyPrefix is the prefix of the keys of secrets in a profile, e.g. "secret.esxi-1".
+	secretKeyPrefix = "secret."
+)
+
+// Credentials are the credentials returned by a credential process or read from a profile.
+// The output of a credential process is a JSON object with the same keys, e.g.
+//
+//	{"sddc_manager_username": "admin@local", "sddc_manager_password": "...", "secrets": {"esxi-1": "..."}}
+type Credentials struct {
+	SddcManagerUsername    string            `json:"sddc_manager_username,omitempty"`
+	SddcManagerPassword    string            `json:"sddc_manager_password,omitempty"`
+	SddcManagerApiKey      string            `json:"sddc_manager_api_key,omitempty"`
+	SddcManagerAccessToken string            `json:"sddc_manager_access_token,omitempty"`
+	InstallerUsername      string            `json:"installer_username,omitempty"`
+	InstallerPassword      string            `json:"installer_password,omitempty"`
+	Secrets                map[string]string `json:"secrets,omitempty"`
+}
+
+// Source describes where credentials are retrieved from. If both are set, the values of the
+// profile take precedence over the output of the command.
+type Source struct {
+	// CredentialProcess is the command which prints the credentials as JSON.
+	CredentialProcess string
+	// CredentialsFile is the path of the credentials file. Profiles are not read if empty.
+	CredentialsFile string
+	// Profile is the name of the profile in the credentials file, DefaultProfile if empty.
+	Profile string
+}
+
+// IsSet reports whether credentials are retrieved from anywhere.
+func (source Source) IsSet() bool {
+	return source.CredentialProcess != "" || source.CredentialsFile != ""
+}
+
+// Secret returns the secret with the given name.
+func (credentials *Credentials) Secret(name string) (string, bool) {
+	if credentials == nil {
+		return "", false
+	}
+	secret, ok := credentials.Secrets[name]
+	return secret, ok
+}
+
+// Load retrieves the credentials from the source. The output of the credential process is
+// overridden by the values of the profile.
+func Load(ctx context.Context, source Source) (*Credentials, error) {
+	credentials := &Credentials{}
+	if source.CredentialProcess != "" {
+		processCredentials, err := Run(ctx, source.CredentialProcess)
+		if err != nil {
+			return nil, err
+		}
+		credentials = processCredentials
+	}
+	if source.CredentialsFile != "" {
+		profile := source.Profile
+		if profile == "" {
+			profile = DefaultProfile
+		}
+		profileCredentials, err := ReadProfile(ctx, source.CredentialsFile, profile)
+		if err != nil {
+			return nil, err
+		}
+		credentials.merge(profileCredentials)
+	}
+
+	return credentials, nil
+}
+
+// Run runs a credential process and parses the credentials it prints to stdout. The command is
+// not run by a shell, arguments with spaces have to be quoted.
+func Run(ctx context.Context, command string) (*Credentials, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential_process %q: %w", command, err)
+	}
+	if len(args) == 0 {
+		return nil, errors.New("credential_process is empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("credential_process %s failed: %w: %s", args[0], err, message)
+		}
+		return nil, fmt.Errorf("credential_process %s failed: %w", args[0], err)
+	}
+
+	var credentials Credentials
+	if err = json.Unmarshal(stdout.Bytes(), &credentials); err != nil {
+		// The output contains secrets, it must not be part of the error
+		return nil, fmt.Errorf("credential_process %s did not print the credentials as a JSON object", args[0])
+	}
+	return &credentials, nil
+}
+
+// ReadProfile reads a profile of a credentials file. The file consists of sections which are
+// the profiles, with "key = value" lines of the keys of Credentials, a credential_process to run
+// and "secret.<name>" keys for other secrets, e.g.
+//
+//	[lab]
+//	sddc_manager_api_key = ...
+//	secret.esxi-1 = ...
+//
+// A "~" at the start of the path is the home directory of the user.
+func ReadProfile(ctx context.Context, path, profile string) (*Credentials, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	values, found, err := readSection(file, profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials file %s: %w", path, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("profile %q not found in the credentials file %s", profile, path)
+	}
+
+	credentials := &Credentials{}
+	if command, ok := values[credentialProcessKey]; ok {
+		if credentials, err = Run(ctx, command); err != nil {
+			return nil, err
+		}
+	}
+
+	profileCredentials := &Credentials{}
+	for key, value := range values {
+		if name, ok := strings.CutPrefix(key, secretKeyPrefix); ok {
+			if profileCredentials.Secrets == nil {
+				profileCredentials.Secrets = make(map[string]string)
+			}
+			profileCredentials.Secrets[name] = value
+			continue
+		}
+		switch key {
+		case "sddc_manager_username":
+			profileCredentials.SddcManagerUsername = value
+		case "sddc_manager_password":
+			profileCredentials.SddcManagerPassword = value
+		case "sddc_manager_api_key":
+			profileCredentials.SddcManagerApiKey = value
+		case "sddc_manager_access_token":
+			profileCredentials.SddcManagerAccessToken = value
+		case "installer_username":
+			profileCredentials.InstallerUsername = value
+		case "installer_password":
+			profileCredentials.InstallerPassword = value
+		case credentialProcessKey:
+		default:
+			return nil, fmt.Errorf("unknown key %q in profile %q of the credentials file %s", key, profile, path)
+		}
+	}
+	credentials.merge(profileCredentials)
+	return credentials, nil
+}
+
+// merge overwrites the credentials with the values which are set in other.
+func (credentials *Credentials) merge(other *Credentials) {
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&credentials.SddcManagerUsername, other.SddcManagerUsername},
+		{&credentials.SddcManagerPassword, other.SddcManagerPassword},
+		{&credentials.SddcManagerApiKey, other.SddcManagerApiKey},
+		{&credentials.SddcManagerAccessToken, other.SddcManagerAccessToken},
+		{&credentials.InstallerUsername, other.InstallerUsername},
+		{&credentials.InstallerPassword, other.InstallerPassword},
+	} {
+		if field.value != "" {
+			*field.target = field.value
+		}
+	}
+	for name, secret := range other.Secrets {
+		if credentials.Secrets == nil {
+			credentials.Secrets = make(map[string]string)
+		}
+		credentials.Secrets[name] = secret
+	}
+}
+
+// readSection returns the "key = value" lines of a section of an INI file. Lines starting with
+// "#" or ";" are comments.
+func readSection(file *os.File, section string) (map[string]string, bool, error) {
+	values := make(map[string]string)
+	found, inSection := false, false
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			inSection = strings.TrimSpace(line[1:len(line)-1]) == section
+			found = found || inSection
+		case inSection:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, false, fmt.Errorf("line %d is not a \"key = value\" line", lineNumber)
+			}
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return values, found, scanner.Err()
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the home directory for %s: %w", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// splitCommand splits a command line into its arguments. Arguments are separated by spaces,
+// single or double quotes group an argument which contains spaces.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,146 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package credential_source
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const helperOutputEnv = "VCF_TEST_CREDENTIAL_PROCESS_OUTPUT"
+
+// TestHelperProcess is the stub credential process run by the tests. It prints the value of
+// helperOutputEnv, or fails if the value starts with "fail:".
+func TestHelperProcess(t *testing.T) {
+	output, ok := os.LookupEnv(helperOutputEnv)
+	if !ok {
+		return
+	}
+	if message, failed := strings.CutPrefix(output, "fail:"); failed {
+		fmt.Fprint(os.Stderr, message)
+		os.Exit(1)
+	}
+	fmt.Print(output)
+	os.Exit(0)
+}
+
+// stubCommand returns a credential_process which prints the given output.
+func stubCommand(t *testing.T, output string) string {
+	t.Setenv(helperOutputEnv, output)
+	return fmt.Sprintf("%q -test.run=^TestHelperProcess$", os.Args[0])
+}
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	command := stubCommand(t, `{"sddc_manager_username": "admin@local", "sddc_manager_password": "s3cret",
+		"secrets": {"esxi-1": "r00t"}}`)
+
+	credentials, err := Run(context.Background(), command)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if credentials.SddcManagerUsername != "admin@local" || credentials.SddcManagerPassword != "s3cret" {
+		t.Fatal("unexpected credentials", credentials)
+	}
+	if secret, ok := credentials.Secret("esxi-1"); !ok || secret != "r00t" {
+		t.Fatal("unexpected secret", secret)
+	}
+}
+
+func TestRun_failure(t *testing.T) {
+	command := stubCommand(t, "fail:vault is sealed")
+
+	_, err := Run(context.Background(), command)
+	if err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestRun_invalidOutput(t *testing.T) {
+	command := stubCommand(t, "password=s3cret")
+
+	_, err := Run(context.Background(), command)
+	if err == nil || strings.Contains(err.Error(), "s3cret") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestReadProfile(t *testing.T) {
+	path := writeCredentialsFile(t, `
+# Credentials of the lab
+[default]
+sddc_manager_username = admin@local
+
+[lab]
+sddc_manager_api_key = key
+secret.esxi-1.vrack.vsphere.local = r00t=pass
+`)
+
+	credentials, err := ReadProfile(context.Background(), path, "lab")
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	expected := &Credentials{
+		SddcManagerApiKey: "key",
+		Secrets:           map[string]string{"esxi-1.vrack.vsphere.local": "r00t=pass"},
+	}
+	if !reflect.DeepEqual(credentials, expected) {
+		t.Fatal("unexpected credentials", credentials)
+	}
+
+	if _, err = ReadProfile(context.Background(), path, "production"); err == nil {
+		t.Fatal("expected an error for a missing profile")
+	}
+}
+
+func TestReadProfile_unknownKey(t *testing.T) {
+	path := writeCredentialsFile(t, "[default]\nsddc_manager_passwd = s3cret\n")
+
+	_, err := ReadProfile(context.Background(), path, DefaultProfile)
+	if err == nil || !strings.Contains(err.Error(), "sddc_manager_passwd") || strings.Contains(err.Error(), "s3cret") {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestReadProfile_credentialProcess(t *testing.T) {
+	command := stubCommand(t, `{"installer_username": "admin@local", "installer_password": "s3cret"}`)
+	path := writeCredentialsFile(t, fmt.Sprintf("[default]\ncredential_process = %s\ninstaller_password = override\n", command))
+
+	credentials, err := ReadProfile(context.Background(), path, DefaultProfile)
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if credentials.InstallerUsername != "admin@local" || credentials.InstallerPassword != "override" {
+		t.Fatal("unexpected credentials", credentials)
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	for command, expected := range map[string][]string{
+		"vault-vcf-creds prod":                 {"vault-vcf-creds", "prod"},
+		`"/opt/my tools/creds"  --profile lab`: {"/opt/my tools/creds", "--profile", "lab"},
+		`creds 'a b' ""`:                       {"creds", "a b", ""},
+	} {
+		args, err := splitCommand(command)
+		if err != nil || !reflect.DeepEqual(args, expected) {
+			t.Errorf("unexpected arguments of %s: %q, %v", command, args, err)
+		}
+	}
+	if _, err := splitCommand(`creds "prod`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
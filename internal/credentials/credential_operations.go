@@ -22,56 +22,85 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 )
 
+// Filter selects the credentials read from SDDC Manager. Empty fields do not filter.
+type Filter struct {
+	ResourceName string
+	ResourceIp   string
+	ResourceType string
+	DomainName   string
+	AccountType  string
+	// The page that is returned on its own, all pages are read if it is 0
+	Page     int
+	PageSize int
+}
+
+// ReadCredentials reads the credentials selected by the filter attributes of a resource or data
+// source. Resources which do not have all filter attributes are not filtered by the others.
 func ReadCredentials(ctx context.Context, data *schema.ResourceData, apiClient *vcf.ClientWithResponses) ([]vcf.Credential, error) {
-	getCredentialsParam := &vcf.GetCredentialsParams{}
-	resourceName, nameOk := data.Get("resource_name").(string)
-	if nameOk && len(resourceName) > 0 {
-		getCredentialsParam.ResourceName = &resourceName
-	}
+	filter := Filter{}
+	filter.ResourceName, _ = data.Get("resource_name").(string)
+	filter.ResourceIp, _ = data.Get("resource_ip").(string)
+	filter.ResourceType, _ = data.Get("resource_type").(string)
+	filter.DomainName, _ = data.Get("domain_name").(string)
+	filter.AccountType, _ = data.Get("account_type").(string)
+	filter.Page, _ = data.Get("page").(int)
+	filter.PageSize, _ = data.Get("page_size").(int)
+	return ReadFilteredCredentials(ctx, filter, apiClient)
+}
 
-	ip, ipOk := data.Get("resource_ip").(string)
-	if ipOk && len(ip) > 0 {
-		getCredentialsParam.ResourceIp = &ip
+// ReadFilteredCredentials reads the credentials selected by a filter.
+func ReadFilteredCredentials(ctx context.Context, filter Filter, apiClient *vcf.ClientWithResponses) ([]vcf.Credential, error) {
+	getCredentialsParam := &vcf.GetCredentialsParams{}
+	if len(filter.ResourceName) > 0 {
+		getCredentialsParam.ResourceName = &filter.ResourceName
 	}
 
-	resType, resTypeOK := data.Get("resource_type").(string)
-	if resTypeOK && len(resType) > 0 {
-		getCredentialsParam.ResourceType = &resType
+	if len(filter.ResourceIp) > 0 {
+		getCredentialsParam.ResourceIp = &filter.ResourceIp
 	}
 
-	domainName, domainNameOk := data.Get("domain_name").(string)
-	if domainNameOk && len(domainName) > 0 {
-		getCredentialsParam.DomainName = &domainName
+	if len(filter.ResourceType) > 0 {
+		getCredentialsParam.ResourceType = &filter.ResourceType
 	}
 
-	accountType, accountTypeOk := data.Get("account_type").(string)
-	if accountTypeOk && len(accountType) > 0 {
-		getCredentialsParam.AccountType = &accountType
+	if len(filter.DomainName) > 0 {
+		getCredentialsParam.DomainName = &filter.DomainName
 	}
 
-	page, pageOk := data.Get("page").(int)
-	if pageOk && page > 0 {
-		pageNum := strconv.Itoa(page)
-		getCredentialsParam.PageNumber = &pageNum
+	if len(filter.AccountType) > 0 {
+		getCredentialsParam.AccountType = &filter.AccountType
 	}
 
-	pageSize, pageSizeOk := data.Get("page_size").(int)
-	if pageSizeOk && pageSize > 0 {
-		pageSizeNum := strconv.Itoa(pageSize)
+	if filter.PageSize > 0 {
+		pageSizeNum := strconv.Itoa(filter.PageSize)
 		getCredentialsParam.PageSize = &pageSizeNum
 	}
 
-	res, err := apiClient.GetCredentialsWithResponse(ctx, getCredentialsParam)
-	if err != nil {
-		return nil, err
-	}
-	pageOfCredential, vcfErr := api_client.GetResponseAs[vcf.PageOfCredential](res)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+	// A page that has been asked for explicitly is returned on its own, otherwise all pages are read
+	if filter.Page > 0 {
+		pageNum := strconv.Itoa(filter.Page)
+		getCredentialsParam.PageNumber = &pageNum
+
+		res, err := apiClient.GetCredentialsWithResponse(ctx, getCredentialsParam)
+		if err != nil {
+			return nil, err
+		}
+		pageOfCredential, vcfErr := api_client.GetResponseAs[vcf.PageOfCredential](res)
+		if vcfErr != nil {
+			api_client.LogError(vcfErr, ctx)
+			return nil, api_client.NewApiError(vcfErr)
+		}
+		if pageOfCredential == nil || pageOfCredential.Elements == nil {
+			return nil, nil
+		}
+		return *pageOfCredential.Elements, nil
 	}
 
-	return *pageOfCredential.Elements, nil
+	return api_client.GetAllPages[vcf.Credential](ctx, func(pageNumber int) (api_client.Response, error) {
+		pageNum := strconv.Itoa(pageNumber)
+		getCredentialsParam.PageNumber = &pageNum
+		return apiClient.GetCredentialsWithResponse(ctx, getCredentialsParam)
+	}, nil)
 }
 
 func FlattenCredentials(creds []vcf.Credential) []map[string]interface{} {
@@ -154,6 +183,28 @@ func mutatePassword(ctx context.Context, data *schema.ResourceData, meta interfa
 	return executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
 }
 
+// PasswordUpdate is a new password of an account of a resource managed by SDDC Manager.
+type PasswordUpdate struct {
+	CredentialType string
+	Username       string
+	Password       string
+}
+
+// UpdateResourcePasswords sets new passwords of accounts of a resource managed by SDDC Manager,
+// e.g. the root account of a host, and waits for the update to complete.
+func UpdateResourcePasswords(ctx context.Context, sddcClient *api_client.SddcManagerClient, resourceType, resourceName string,
+	updates ...PasswordUpdate) error {
+	creds := make([]interface{}, len(updates))
+	for i, update := range updates {
+		creds[i] = map[string]interface{}{
+			"credential_type": update.CredentialType,
+			"user_name":       update.Username,
+			"password":        update.Password,
+		}
+	}
+	return executeCredentialsUpdate(ctx, makeCredentialsChangeSpec(resourceType, resourceName, creds, Update), sddcClient)
+}
+
 func RemoveAutoRotatePolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) error {
 	resourceType := data.Get("resource_type").(string)
 	resourceId := data.Get("resource_id").(string)
@@ -204,9 +255,9 @@ func executeCredentialsUpdate(ctx context.Context, updateSpec *vcf.CredentialsUp
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return errors.New(*vcfErr.Message)
+		return api_client.NewApiError(vcfErr)
 	}
-	return api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask()
+	return sddcClient.NewTaskTracker(ctx, *task.Id).WaitForTask()
 }
 
 func CreatePasswordChangeID(data *schema.ResourceData, operation string) (string, error) {
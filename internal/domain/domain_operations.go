@@ -6,7 +6,6 @@ package domain
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sort"
 
@@ -69,22 +68,18 @@ func ReadAndSetClustersDataToDomainResource(domainClusterRefs []vcf.ClusterRefer
 		clusterIdsInTheCurrentDomain[clusterReference.Id] = true
 	}
 
-	clustersResult, err := apiClient.GetClustersWithResponse(ctx, nil)
+	allClusters, err := api_client.GetList[vcf.Cluster](ctx, func() (api_client.Response, error) {
+		return apiClient.GetClustersWithResponse(ctx, nil)
+	})
 	if err != nil {
 		return err
 	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfCluster](clustersResult)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return errors.New(*vcfErr.Message)
-	}
 	domainClusterData := data.Get("cluster")
 	domainClusterDataList := domainClusterData.([]interface{})
-	allClusters := page.Elements
 	for _, domainClusterRaw := range domainClusterDataList {
 		domainCluster := domainClusterRaw.(map[string]interface{})
 		if allClusters != nil {
-			for _, clusterObj := range *allClusters {
+			for _, clusterObj := range allClusters {
 				_, ok := clusterIdsInTheCurrentDomain[*clusterObj.Id]
 				// go over clusters that are in the domain, skip the rest
 				if !ok {
@@ -114,7 +109,7 @@ func SetBasicDomainAttributes(ctx context.Context, domainId string, data *schema
 	domain, vcfErr := api_client.GetResponseAs[vcf.Domain](domainRes)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return nil, api_client.NewApiError(vcfErr)
 	}
 
 	data.SetId(*domain.Id)
@@ -213,7 +208,7 @@ func setClustersDataToDomainDataSource(domainClusterRefs []vcf.ClusterReference,
 		clusterRef, vcfErr := api_client.GetResponseAs[vcf.Cluster](res)
 		if vcfErr != nil {
 			api_client.LogError(vcfErr, ctx)
-			return errors.New(*vcfErr.Message)
+			return api_client.NewApiError(vcfErr)
 		}
 		flattenedCluster, err := cluster.FlattenCluster(ctx, clusterRef, apiClient)
 		if err != nil {
@@ -229,7 +224,7 @@ func setClustersDataToDomainDataSource(domainClusterRefs []vcf.ClusterReference,
 
 func generateNsxSpecFromResourceData(data *schema.ResourceData) (*vcf.NsxTSpec, error) {
 	if nsxConfigRaw, ok := data.GetOk("nsx_configuration"); ok && len(nsxConfigRaw.([]interface{})) > 0 {
-		nsxConfigList := nsxConfigRaw.([]interface{})
+		nsxConfigList := utils.GetWithWriteOnly(data, "nsx_configuration").([]interface{})
 		nsxConfigListEntry := nsxConfigList[0].(map[string]interface{})
 		nsxSpec, err := network.TryConvertToNsxSpec(nsxConfigListEntry)
 		return nsxSpec, err
@@ -239,7 +234,7 @@ func generateNsxSpecFromResourceData(data *schema.ResourceData) (*vcf.NsxTSpec,
 
 func generateVcenterSpecFromResourceData(data *schema.ResourceData) (*vcf.VcenterSpec, error) {
 	if vcenterConfigRaw, ok := data.GetOk("vcenter_configuration"); ok && len(vcenterConfigRaw.([]interface{})) > 0 {
-		vcenterConfigList := vcenterConfigRaw.([]interface{})
+		vcenterConfigList := utils.GetWithWriteOnly(data, "vcenter_configuration").([]interface{})
 		vcenterConfigListEntry := vcenterConfigList[0].(map[string]interface{})
 		vcenterSpec, err := vcenter.TryConvertToVcenterSpec(vcenterConfigListEntry)
 		return vcenterSpec, err
@@ -249,7 +244,7 @@ func generateVcenterSpecFromResourceData(data *schema.ResourceData) (*vcf.Vcente
 
 func generateComputeSpecFromResourceData(data *schema.ResourceData) (*vcf.ComputeSpec, error) {
 	if clusterConfigRaw, ok := data.GetOk("cluster"); ok && !validationUtils.IsEmpty(clusterConfigRaw) {
-		clusterConfigList := clusterConfigRaw.([]interface{})
+		clusterConfigList := utils.GetWithWriteOnly(data, "cluster").([]interface{})
 		result := &vcf.ComputeSpec{}
 		var clusterSpecs []vcf.ClusterSpec
 		for _, clusterConfigListEntry := range clusterConfigList {
@@ -266,13 +261,13 @@ func generateComputeSpecFromResourceData(data *schema.ResourceData) (*vcf.Comput
 }
 
 func generateSsoSpecFromResourceData(data *schema.ResourceData) (*vcf.SsoDomainSpec, error) {
-	if ssoConfigRaw, ok := data.GetOk("sso"); ok {
-		ssoConfigList := ssoConfigRaw.([]interface{})
+	if _, ok := data.GetOk("sso"); ok {
+		ssoConfigList := utils.GetWithWriteOnly(data, "sso").([]interface{})
 		ssoConfig := ssoConfigList[0].(map[string]interface{})
 
 		return &vcf.SsoDomainSpec{
 			SsoDomainName:     utils.ToPointer[string](ssoConfig["domain_name"].(string)),
-			SsoDomainPassword: utils.ToPointer[string](ssoConfig["domain_password"].(string)),
+			SsoDomainPassword: utils.ToPointer[string](utils.GetBlockSecret(ssoConfig, "domain_password")),
 		}, nil
 	}
 
@@ -6,7 +6,6 @@ package network
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -55,11 +54,14 @@ func NsxSchema() *schema.Resource {
 			},
 			"nsx_manager_admin_password": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Sensitive:    true,
-				Description:  "NSX Manager admin user password",
+				Description:  "NSX Manager admin user password. Either nsx_manager_admin_password or nsx_manager_admin_password_wo is required",
 				ValidateFunc: validationutils.ValidatePassword,
 			},
+			"nsx_manager_admin_password_wo": resource_utils.WriteOnlySchema("nsx_manager_admin_password",
+				"NSX Manager admin user password", validationutils.ValidatePassword),
+			"nsx_manager_admin_password_wo_version": resource_utils.WriteOnlyVersionSchema("nsx_manager_admin_password", false),
 			"nsx_manager_audit_password": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -67,6 +69,9 @@ func NsxSchema() *schema.Resource {
 				Description:  "NSX Manager audit user password",
 				ValidateFunc: validationutils.ValidatePassword,
 			},
+			"nsx_manager_audit_password_wo": resource_utils.WriteOnlySchema("nsx_manager_audit_password",
+				"NSX Manager audit user password", validationutils.ValidatePassword),
+			"nsx_manager_audit_password_wo_version": resource_utils.WriteOnlyVersionSchema("nsx_manager_audit_password", false),
 			"nsx_manager_node": {
 				Type:        schema.TypeList,
 				Required:    true,
@@ -96,9 +101,9 @@ func TryConvertToNsxSpec(object map[string]interface{}) (*vcf.NsxTSpec, error) {
 	if object["nsx_manager_node"] == nil {
 		return nil, fmt.Errorf("cannot convert to NsxTSpec, nsx_manager is required")
 	}
-	nsxManagerAdminPassword := object["nsx_manager_admin_password"].(string)
+	nsxManagerAdminPassword := resource_utils.GetBlockSecret(object, "nsx_manager_admin_password")
 	if len(nsxManagerAdminPassword) == 0 {
-		return nil, fmt.Errorf("cannot convert to NsxTSpec, nsx_manager_admin_password is required")
+		return nil, fmt.Errorf("cannot convert to NsxTSpec, nsx_manager_admin_password or nsx_manager_admin_password_wo is required")
 	}
 
 	result := &vcf.NsxTSpec{}
@@ -110,8 +115,8 @@ func TryConvertToNsxSpec(object map[string]interface{}) (*vcf.NsxTSpec, error) {
 		result.FormFactor = resource_utils.ToStringPointer(formFactor)
 	}
 
-	if nsxManagerAuditPassword, ok := object["nsx_manager_audit_password"]; ok && !validationutils.IsEmpty(nsxManagerAuditPassword) {
-		result.NsxManagerAuditPassword = resource_utils.ToStringPointer(nsxManagerAuditPassword)
+	if nsxManagerAuditPassword := resource_utils.GetBlockSecret(object, "nsx_manager_audit_password"); nsxManagerAuditPassword != "" {
+		result.NsxManagerAuditPassword = &nsxManagerAuditPassword
 	}
 	nsxManagerList := object["nsx_manager_node"].([]interface{})
 	if len(nsxManagerList) == 0 {
@@ -146,7 +151,7 @@ func FlattenNsxClusterRef(ctx context.Context, nsxtClusterRef vcf.NsxTClusterRef
 	nsxtCluster, vcfErr := api_client.GetResponseAs[vcf.NsxTCluster](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return nil, api_client.NewApiError(vcfErr)
 	}
 	if nsxtCluster.Nodes != nil {
 		nsxtManagerNodes := *nsxtCluster.Nodes
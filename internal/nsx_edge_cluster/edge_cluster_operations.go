@@ -24,9 +24,9 @@ const (
 func GetNsxEdgeClusterCreationSpec(data *schema.ResourceData, client *vcf.ClientWithResponses) (*vcf.EdgeClusterCreationSpec, error) {
 	// No other types are supported yet
 	clusterType := clusterTypeNsxT
-	adminPassword := data.Get("admin_password").(string)
-	auditPassword := data.Get("audit_password").(string)
-	rootPassword := data.Get("root_password").(string)
+	adminPassword := resource_utils.GetSecret(data, "admin_password")
+	auditPassword := resource_utils.GetSecret(data, "audit_password")
+	rootPassword := resource_utils.GetSecret(data, "root_password")
 	name := data.Get("name").(string)
 	profileType := data.Get("profile_type").(string)
 	profileSpec := getClusterProfileSpec(data)
@@ -69,7 +69,7 @@ func GetNsxEdgeClusterCreationSpec(data *schema.ResourceData, client *vcf.Client
 		highAvailability = resource_utils.ToPointer[string](data.Get("high_availability").(string))
 	}
 
-	nodes := data.Get("edge_node").([]interface{})
+	nodes := resource_utils.GetWithWriteOnly(data, "edge_node").([]interface{})
 	nodeSpecs := make([]vcf.NsxTEdgeNodeSpec, 0, len(nodes))
 
 	for _, node := range nodes {
@@ -139,9 +139,9 @@ func GetNsxEdgeClusterExpansionSpec(currentNodes []vcf.EdgeNodeReference,
 	for _, newNode := range newNodes {
 		node := newNode.(map[string]interface{})
 
-		adminPassword := node["admin_password"].(string)
-		auditPassword := node["audit_password"].(string)
-		rootPassword := node["root_password"].(string)
+		adminPassword := resource_utils.GetBlockSecret(node, "admin_password")
+		auditPassword := resource_utils.GetBlockSecret(node, "audit_password")
+		rootPassword := resource_utils.GetBlockSecret(node, "root_password")
 
 		spec.EdgeNodeAdminPassword = adminPassword
 		spec.EdgeNodeAuditPassword = auditPassword
@@ -279,7 +279,7 @@ func getBgpPeerSpecs(bgpPeersRaw []interface{}) (*[]vcf.BgpPeerSpec, error) {
 
 	for _, peer := range bgpPeersRaw {
 		ip := peer.(map[string]interface{})["ip"].(string)
-		password := peer.(map[string]interface{})["password"].(string)
+		password := resource_utils.GetBlockSecret(peer.(map[string]interface{}), "password")
 		asn := peer.(map[string]interface{})["asn"].(string)
 
 		asnInt, err := strconv.ParseInt(asn, 10, 64)
@@ -327,23 +327,18 @@ func getClusterProfileSpec(data *schema.ResourceData) *vcf.NsxTEdgeClusterProfil
 }
 
 func getComputeCluster(name string, client *vcf.ClientWithResponses) (*vcf.Cluster, error) {
-	ok, err := client.GetClustersWithResponse(context.TODO(), nil)
-
+	ctx := context.TODO()
+	cluster, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return client.GetClustersWithResponse(ctx, nil)
+	}, func(cluster vcf.Cluster) bool {
+		return cluster.Name != nil && *cluster.Name == name
+	})
 	if err != nil {
 		return nil, err
 	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfCluster](ok)
-	if vcfErr != nil {
-		return nil, errors.New(*vcfErr.Message)
-	}
-
-	if page.Elements != nil && len(*page.Elements) > 0 {
-		for _, cluster := range *page.Elements {
-			if *cluster.Name == name {
-				return &cluster, nil
-			}
-		}
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster %s not found", name)
 	}
 
-	return nil, fmt.Errorf("cluster %s not found", name)
+	return cluster, nil
 }
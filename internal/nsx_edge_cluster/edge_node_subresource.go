@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -36,22 +37,28 @@ func EdgeNodeSchema() *schema.Resource {
 			},
 			"admin_password": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The administrator password for the edge node",
+				Optional:     true,
+				Description:  "The administrator password for the edge node. Either admin_password or admin_password_wo is required",
 				ValidateFunc: validationUtils.ValidateNsxEdgePassword,
 			},
+			"admin_password_wo":         resource_utils.WriteOnlySchema("admin_password", "The administrator password for the edge node", validationUtils.ValidateNsxEdgePassword),
+			"admin_password_wo_version": resource_utils.WriteOnlyVersionSchema("admin_password", false),
 			"audit_password": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The audit password for the edge node",
+				Optional:     true,
+				Description:  "The audit password for the edge node. Either audit_password or audit_password_wo is required",
 				ValidateFunc: validationUtils.ValidateNsxEdgePassword,
 			},
+			"audit_password_wo":         resource_utils.WriteOnlySchema("audit_password", "The audit password for the edge node", validationUtils.ValidateNsxEdgePassword),
+			"audit_password_wo_version": resource_utils.WriteOnlyVersionSchema("audit_password", false),
 			"root_password": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The root user password for the edge node",
+				Optional:     true,
+				Description:  "The root user password for the edge node. Either root_password or root_password_wo is required",
 				ValidateFunc: validationUtils.ValidateNsxEdgePassword,
 			},
+			"root_password_wo":         resource_utils.WriteOnlySchema("root_password", "The root user password for the edge node", validationUtils.ValidateNsxEdgePassword),
+			"root_password_wo_version": resource_utils.WriteOnlyVersionSchema("root_password", false),
 			"tep1_ip": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -173,9 +180,11 @@ func BgpPeerSchema() *schema.Resource {
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Description: "Password",
 			},
+			"password_wo":         resource_utils.WriteOnlySchema("password", "Password", nil),
+			"password_wo_version": resource_utils.WriteOnlyVersionSchema("password", false),
 			"asn": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/certificates" // Ensure this package exists and contains necessary methods
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func DataSourceCertificate() *schema.Resource {
@@ -192,7 +193,7 @@ func dataCertificateRead(ctx context.Context, data *schema.ResourceData, meta in
 	cert, err := certificates.ReadCertificate(ctx, apiClient, domainId, resourceFqdn)
 	if err != nil {
 		log.Printf("[ERROR] Failed to read certificate: %s", err)
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	if cert == nil {
@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -14,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/vcf-sdk-go/vcf"
 )
 
@@ -295,7 +295,7 @@ func dataSourceHostRead(ctx context.Context, d *schema.ResourceData, meta interf
 	fqdn := d.Get("fqdn").(string)
 	host, err := getHostByFqdn(ctx, apiClient, fqdn)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	d.SetId(*host.Id)
@@ -364,7 +364,7 @@ func dataSourceHostRead(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	if err := d.Set("cpu", []interface{}{cpu}); err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	// Memory information.
@@ -429,22 +429,18 @@ func dataSourceHostRead(ctx context.Context, d *schema.ResourceData, meta interf
 }
 
 func getHostByFqdn(ctx context.Context, apiClient *vcf.ClientWithResponses, fqdn string) (*vcf.Host, error) {
-	hostsResponse, err := apiClient.GetHostsWithResponse(ctx, &vcf.GetHostsParams{})
+	// The pages of hosts are numbered from 0 like the pages of the pager
+	hostElement, err := api_client.FindInPages(ctx, func(pageNumber int) (api_client.Response, error) {
+		return apiClient.GetHostsWithResponse(ctx, &vcf.GetHostsParams{Page: &pageNumber})
+	}, func(hostElement vcf.Host) bool {
+		return hostElement.Fqdn != nil && *hostElement.Fqdn == fqdn
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	resp, vcfErr := api_client.GetResponseAs[vcf.PageOfHost](hostsResponse)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
-	}
-
-	for _, hostElement := range *resp.Elements {
-		if *hostElement.Fqdn == fqdn {
-			return &hostElement, nil
-		}
+	if hostElement == nil {
+		return nil, fmt.Errorf("host FQDN '%s' not found", fqdn)
 	}
 
-	return nil, fmt.Errorf("host FQDN '%s' not found", fqdn)
+	return hostElement, nil
 }
@@ -5,13 +5,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 )
 
 func TestAccDataSourceVcfHost(t *testing.T) {
@@ -47,3 +51,17 @@ func testAccDataSourceVcfHostConfig(hostFqdn string) string {
     }
     `, hostFqdn)
 }
+
+func TestGetHostByFqdn_lastPage(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	for i := 1; i <= 3; i++ {
+		server.AddHost(vcf.Host{Fqdn: utils.ToStringPointer(fmt.Sprintf("esxi-%d.vrack.vsphere.local", i))})
+	}
+	server.SetPageSize(1)
+
+	host, err := getHostByFqdn(context.Background(), client.ApiClient, "esxi-3.vrack.vsphere.local")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "esxi-3.vrack.vsphere.local", *host.Fqdn)
+	}
+}
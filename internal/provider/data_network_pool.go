@@ -13,6 +13,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func DataSourceNetworkPool() *schema.Resource {
@@ -97,7 +98,7 @@ func dataSourceNetworkPoolRead(ctx context.Context, d *schema.ResourceData, meta
 	name := d.Get("name").(string)
 	networkPool, err := getNetworkPoolByName(ctx, apiClient, name)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	d.SetId(*networkPool.Id)
@@ -108,29 +109,19 @@ func dataSourceNetworkPoolRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func getNetworkPoolByName(ctx context.Context, apiClient *vcf.ClientWithResponses, name string) (*vcf.NetworkPool, error) {
-	networkPoolsRes, err := apiClient.GetNetworkPoolWithResponse(ctx)
+	networkPool, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return apiClient.GetNetworkPoolWithResponse(ctx)
+	}, func(networkPool vcf.NetworkPool) bool {
+		return networkPool.Name == name
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	resp, vcfErr := api_client.GetResponseAs[vcf.PageOfNetworkPool](networkPoolsRes)
-
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
-	}
-
-	if resp == nil || resp.Elements == nil {
+	if networkPool == nil {
 		return nil, errors.New("network pool not found")
 	}
 
-	for _, networkPool := range *resp.Elements {
-		if networkPool.Name == name {
-			return &networkPool, nil
-		}
-	}
-
-	return nil, errors.New("network pool not found")
+	return networkPool, nil
 }
 
 func flattenNetworks(networks []vcf.Network) []interface{} {
@@ -15,6 +15,8 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/cluster"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func DataSourceCluster() *schema.Resource {
@@ -49,7 +51,7 @@ func DataSourceCluster() *schema.Resource {
 				Type:        schema.TypeList,
 				Computed:    true,
 				Description: "List of ESXi host information present in the Cluster",
-				Elem:        cluster.HostSpecSchema(),
+				Elem:        utils.WithoutWriteOnly(cluster.HostSpecSchema()),
 			},
 			"vds": {
 				Type:        schema.TypeList,
@@ -86,7 +88,7 @@ func dataSourceClusterRead(ctx context.Context, data *schema.ResourceData, meta
 	clusterId := data.Get("cluster_id").(string)
 	_, err := cluster.ImportCluster(ctx, data, apiClient, clusterId)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	return nil
 }
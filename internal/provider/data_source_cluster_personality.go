@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
 	"github.com/vmware/vcf-sdk-go/vcf"
 )
 
@@ -28,11 +29,16 @@ type DataSourceClusterPersonalityModel struct {
 	Name types.String `tfsdk:"name"`
 }
 
-func (d *DataSourceClusterPersonality) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+func (d *DataSourceClusterPersonality) Configure(_ context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	d.client = req.ProviderData.(*api_client.SddcManagerClient).ApiClient
+	client, err := getSddcManagerClient(req.ProviderData)
+	if err != nil {
+		res.Diagnostics.AddError("SDDC Manager is not configured", err.Error())
+		return
+	}
+	d.client = client.ApiClient
 }
 
 func (d *DataSourceClusterPersonality) Metadata(_ context.Context, _ datasource.MetadataRequest, res *datasource.MetadataResponse) {
@@ -58,33 +64,27 @@ func (d *DataSourceClusterPersonality) Schema(_ context.Context, _ datasource.Sc
 
 func (d *DataSourceClusterPersonality) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
 	var data DataSourceClusterPersonalityModel
+	ctx, span := tracing.StartSpan(ctx, "data.vcf_cluster_personality.Read", tracing.AttributeResourceType.String("vcf_cluster_personality"))
+	defer func() { endFrameworkSpan(span, res.Diagnostics, data.ID.ValueString()) }()
 	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
 	tflog.Debug(ctx, fmt.Sprintf("Looking for personality '%s'", data.Name.ValueString()))
-	response, err := d.client.GetPersonalitiesWithResponse(ctx, &vcf.GetPersonalitiesParams{
-		PersonalityName: data.Name.ValueStringPointer(),
+	personality, err := api_client.FindInPages(ctx, func(pageNumber int) (api_client.Response, error) {
+		return d.client.GetPersonalitiesWithResponse(ctx, personalitiesPage(data.Name.ValueStringPointer(), pageNumber))
+	}, func(vcf.Personality) bool {
+		return true
 	})
 	if err != nil {
-		res.Diagnostics.Append(diag.NewErrorDiagnostic(err.Error(), ""))
+		res.Diagnostics.Append(newApiErrorDiagnostic(err))
 		return
 	}
 
-	responsePage, vcfErr := api_client.GetResponseAs[vcf.PageOfPersonality](response)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		res.Diagnostics.Append(diag.NewErrorDiagnostic(*vcfErr.ErrorType, *vcfErr.Message))
-		return
-	}
-
-	if responsePage == nil || responsePage.Elements == nil || len(*responsePage.Elements) == 0 {
+	if personality == nil {
 		res.Diagnostics.Append(diag.NewErrorDiagnostic(
 			fmt.Sprintf("personality with name '%s' not found", data.Name.ValueString()), ""))
 		return
 	}
 
-	personalities := *responsePage.Elements
-	personality := personalities[0]
-
 	tflog.Debug(ctx, fmt.Sprintf("Personality '%s' found, reading data", data.Name.ValueString()))
 
 	data.ID = types.StringValue(*personality.PersonalityId)
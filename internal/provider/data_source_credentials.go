@@ -14,6 +14,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func DataSourceCredentials() *schema.Resource {
@@ -54,14 +55,14 @@ func DataSourceCredentials() *schema.Resource {
 				Type:         schema.TypeInt,
 				Default:      0,
 				Optional:     true,
-				Description:  "The page of credentials that is returned as result.",
+				Description:  "The page of credentials that is returned as result. By default the credentials of all pages are returned.",
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"page_size": {
 				Type:         schema.TypeInt,
 				Default:      0,
 				Optional:     true,
-				Description:  "the size of the credentials list . Default is 0 so the user will get all records in one page",
+				Description:  "The number of credentials retrieved per request. Default is 0 so all records are retrieved in one request",
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"credentials": {
@@ -78,7 +79,7 @@ func dataCredentialsRead(ctx context.Context, data *schema.ResourceData, meta in
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 	creds, err := credentials.ReadCredentials(ctx, data, apiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	flatCredentials := credentials.FlattenCredentials(creds)
@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -18,6 +17,8 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/domain"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
 )
 
@@ -43,19 +44,19 @@ func DataSourceDomain() *schema.Resource {
 				Type:        schema.TypeList,
 				Computed:    true,
 				Description: "The cluster references associated with the workload domain",
-				Elem:        clusterSubresourceSchema(),
+				Elem:        utils.WithoutWriteOnly(clusterSubresourceSchema()),
 			},
 			"nsx_configuration": {
 				Type:        schema.TypeList,
 				Computed:    true,
 				Description: "The NSX Manager cluster references associated with the workload domain.",
-				Elem:        network.NsxSchema(),
+				Elem:        utils.WithoutWriteOnly(network.NsxSchema()),
 			},
 			"vcenter_configuration": {
 				Type:        schema.TypeList,
 				Computed:    true,
 				Description: "The vCenter Server instance references associated with the workload domain.",
-				Elem:        vcenter.VCSubresourceSchema(),
+				Elem:        utils.WithoutWriteOnly(vcenter.VCSubresourceSchema()),
 			},
 			"status": {
 				Type:        schema.TypeString,
@@ -99,36 +100,31 @@ func dataSourceDomainRead(ctx context.Context, data *schema.ResourceData, meta i
 
 		domainInfo, err := getDomainByName(ctx, apiClient, domainName)
 		if err != nil {
-			return diag.FromErr(err)
+			return validationutils.ConvertVcfErrorToDiag(err)
 		}
 		domainId = *domainInfo.Id
 	}
 
 	_, err := domain.ImportDomain(ctx, data, apiClient, domainId, true)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
 }
 
 func getDomainByName(ctx context.Context, apiClient *vcf.ClientWithResponses, name string) (*vcf.Domain, error) {
-	domainsResponse, err := apiClient.GetDomainsWithResponse(ctx, &vcf.GetDomainsParams{})
+	domainElement, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return apiClient.GetDomainsWithResponse(ctx, &vcf.GetDomainsParams{})
+	}, func(domainElement vcf.Domain) bool {
+		return domainElement.Name != nil && *domainElement.Name == name
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	resp, vcfErr := api_client.GetResponseAs[vcf.PageOfDomain](domainsResponse)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
-	}
-
-	for _, domainElement := range *resp.Elements {
-		if *domainElement.Name == name {
-			return &domainElement, nil
-		}
+	if domainElement == nil {
+		return nil, fmt.Errorf("domain name '%s' not found", name)
 	}
 
-	return nil, fmt.Errorf("domain name '%s' not found", name)
+	return domainElement, nil
 }
@@ -0,0 +1,50 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceSecret() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretRead,
+		Description: "Retrieves a secret, e.g. the password of an ESXi host or an NSX Edge node, from the credential process or the credentials file profile configured in the provider.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the secret, a key of \"secrets\" in the output of the credential process or a \"secret.<name>\" key of the profile.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The value of the secret.",
+			},
+		},
+	}
+}
+
+func dataSourceSecretRead(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := data.Get("name").(string)
+	clients, _ := meta.(*providerClients)
+	if clients == nil || clients.credentials == nil {
+		return diag.Errorf("secret %q not found, set credential_process or profile in the provider configuration", name)
+	}
+	secret, ok := clients.credentials.Secret(name)
+	if !ok {
+		return diag.Errorf("secret %q not found in the output of the credential process or the profile", name)
+	}
+
+	data.SetId(name)
+	_ = data.Set("value", secret)
+	return nil
+}
@@ -0,0 +1,90 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dryRunValidations lists the operations of the resources which SDDC Manager or the VCF
+// Installer can validate without running them. In dry-run mode, these operations build their
+// spec and only run its validation, the other operations fail without sending any request.
+var dryRunValidations = map[string][]string{
+	"vcf_cluster":      {"Create", "Update"},
+	"vcf_domain":       {"Create", "Update"},
+	"vcf_edge_cluster": {"Create", "Update"},
+	"vcf_host":         {"Create"},
+	"vcf_instance":     {"Create"},
+}
+
+const (
+	dryRunPassedDetail       = "The provider is configured with dry_run = true, the change has been validated and has not been submitted."
+	dryRunNotValidatedDetail = "The provider is configured with dry_run = true and there is no validation of the change, the change has not been submitted."
+)
+
+// dryRunKey is the key of the context value which marks the operations run in dry-run mode.
+type dryRunKey struct{}
+
+func withDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// isDryRun reports whether the operation of the context must only validate its changes.
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// dryRunProvider runs the create, update and delete operations of all resources of the
+// provider in dry-run mode if dry_run is set in the provider configuration.
+func dryRunProvider(provider *schema.Provider) *schema.Provider {
+	for resourceType, resource := range provider.ResourcesMap {
+		resource.CreateContext = dryRunContextFunc(resourceType, "Create", resource.CreateContext)
+		resource.UpdateContext = dryRunContextFunc(resourceType, "Update", resource.UpdateContext)
+		resource.DeleteContext = dryRunContextFunc(resourceType, "Delete", resource.DeleteContext)
+	}
+	return provider
+}
+
+func dryRunContextFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](
+	resourceType, operation string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if clients, ok := meta.(*providerClients); !ok || !clients.dryRun {
+			return f(ctx, data, meta)
+		}
+		if !slices.Contains(dryRunValidations[resourceType], operation) {
+			return dryRunNotValidated(fmt.Sprintf("%s of %s", operation, resourceType))
+		}
+		return f(withDryRun(ctx), data, meta)
+	}
+}
+
+// dryRunPassed returns the result of an operation whose changes have passed the validation in
+// dry-run mode. It is an error, so that Terraform records no change in the state.
+func dryRunPassed(operation string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Dry run: %s passed the validation", operation),
+		Detail:   dryRunPassedDetail,
+	}}
+}
+
+// dryRunNotValidated returns the result of an operation which cannot be validated without
+// running it in dry-run mode.
+func dryRunNotValidated(operation string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Dry run: %s cannot be validated", operation),
+		Detail:   dryRunNotValidatedDetail,
+	}}
+}
@@ -0,0 +1,198 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func TestDryRunProvider_update(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+
+	resource := Provider().ResourcesMap["vcf_cluster"]
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":      "sfo-w01-cl02",
+		"domain_id": domainId,
+	})
+	data.SetId(clusterId)
+
+	diags := resource.UpdateContext(context.Background(), data, &providerClients{sddcManager: client, dryRun: true})
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Dry run: the update of cluster "+clusterId+" passed the validation", diags[0].Summary)
+	}
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/clusters/"+clusterId+"/validations"))
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/clusters/"+clusterId))
+	cluster, _ := server.Cluster(clusterId)
+	assert.Equal(t, "sfo-w01-cl01", *cluster.Name)
+}
+
+func TestDryRunProvider_failedValidation(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+	server.SetValidationChecks(vcf.ValidationCheck{
+		Description:   utils.ToStringPointer("Validate cluster name"),
+		ResultStatus:  fake_server.ValidationFailed,
+		ErrorResponse: &vcf.Error{Message: utils.ToStringPointer("Cluster name is already in use")},
+	})
+
+	resource := Provider().ResourcesMap["vcf_cluster"]
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":      "sfo-w01-cl02",
+		"domain_id": domainId,
+	})
+	data.SetId(clusterId)
+
+	diags := resource.UpdateContext(context.Background(), data, &providerClients{sddcManager: client, dryRun: true})
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Validate cluster name", diags[0].Summary)
+	}
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/clusters/"+clusterId))
+}
+
+func TestDryRunProvider_notValidated(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+
+	resource := Provider().ResourcesMap["vcf_cluster"]
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":      "sfo-w01-cl01",
+		"domain_id": domainId,
+	})
+	data.SetId(clusterId)
+
+	diags := resource.DeleteContext(context.Background(), data, &providerClients{sddcManager: client, dryRun: true})
+
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "Dry run: Delete of vcf_cluster cannot be validated", diags[0].Summary)
+	}
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/clusters/"+clusterId))
+	assert.Zero(t, server.RequestCount(http.MethodDelete, "/v1/clusters/"+clusterId))
+	_, ok := server.Cluster(clusterId)
+	assert.True(t, ok)
+}
+
+func TestResourceHostCreate_dryRun(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	diags := resourceHostCreate(withDryRun(context.Background()), data, client)
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Dry run: the commissioning of host esxi-1.vrack.vsphere.local passed the validation", diags[0].Summary)
+	}
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/hosts/validations"))
+	assert.Zero(t, server.RequestCount(http.MethodPost, "/v1/hosts"))
+	assert.Empty(t, data.Id())
+}
+
+func TestResourceHostCreate_dryRunValidationInProgress(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	server.SetValidationPolls(2)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	diags := resourceHostCreate(withDryRun(context.Background()), data, client)
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Dry run: the commissioning of host esxi-1.vrack.vsphere.local passed the validation", diags[0].Summary)
+	}
+	assert.Zero(t, server.RequestCount(http.MethodPost, "/v1/hosts"))
+}
+
+func TestResourceHostCreate_dryRunInterrupted(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	server.SetValidationPolls(1000)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	ctx, cancel := context.WithTimeout(withDryRun(context.Background()), 50*time.Millisecond)
+	defer cancel()
+	diags := resourceHostCreate(ctx, data, client)
+
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "stopped waiting")
+	}
+}
+
+func TestResourceDomainUpdate_dryRun(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{Name: utils.ToStringPointer("sfo-w01")})
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, map[string]interface{}{
+		"name": "sfo-w02",
+	})
+	data.SetId(domainId)
+
+	diags := resourceDomainUpdate(withDryRun(context.Background()), data, client)
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Dry run: the update of domain "+domainId+" passed the validation", diags[0].Summary)
+	}
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/domains/"+domainId))
+	domain, _ := server.Domain(domainId)
+	assert.Equal(t, "sfo-w01", *domain.Name)
+}
+
+func TestResourceDomainUpdate_dryRunRunningOperation(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{Name: utils.ToStringPointer("sfo-w01")})
+	_, unlock, err := lockDomain(context.Background(), client, domainId)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer unlock()
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, map[string]interface{}{
+		"name": "sfo-w02",
+	})
+	data.SetId(domainId)
+	ctx, cancel := context.WithTimeout(withDryRun(context.Background()), time.Second)
+	defer cancel()
+
+	diags := resourceDomainUpdate(ctx, data, client)
+
+	// The dry run does not wait for the running operation on the domain
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Dry run: the update of domain "+domainId+" passed the validation", diags[0].Summary)
+	}
+}
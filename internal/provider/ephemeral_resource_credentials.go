@@ -0,0 +1,252 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
+)
+
+type CredentialResourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	Domain types.String `tfsdk:"domain"`
+	Ip     types.String `tfsdk:"ip"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+}
+
+type CredentialModel struct {
+	Id                      types.String              `tfsdk:"id"`
+	UserName                types.String              `tfsdk:"user_name"`
+	Password                types.String              `tfsdk:"password"`
+	AccountType             types.String              `tfsdk:"account_type"`
+	CredentialType          types.String              `tfsdk:"credential_type"`
+	AutoRotateFrequencyDays types.Int64               `tfsdk:"auto_rotate_frequency_days"`
+	AutoRotateNextSchedule  types.String              `tfsdk:"auto_rotate_next_schedule"`
+	CreationTime            types.String              `tfsdk:"creation_time"`
+	ModificationTime        types.String              `tfsdk:"modification_time"`
+	Resource                []CredentialResourceModel `tfsdk:"resource"`
+}
+
+type EphemeralResourceCredentialsModel struct {
+	ResourceName types.String      `tfsdk:"resource_name"`
+	ResourceIp   types.String      `tfsdk:"resource_ip"`
+	ResourceType types.String      `tfsdk:"resource_type"`
+	DomainName   types.String      `tfsdk:"domain_name"`
+	AccountType  types.String      `tfsdk:"account_type"`
+	Page         types.Int64       `tfsdk:"page"`
+	PageSize     types.Int64       `tfsdk:"page_size"`
+	Credentials  []CredentialModel `tfsdk:"credentials"`
+}
+
+// EphemeralResourceCredentials reads the same credentials as the vcf_credentials data source,
+// but its result is only available during the run and is never stored in the plan or state,
+// e.g. to configure other providers with passwords managed by SDDC Manager.
+type EphemeralResourceCredentials struct {
+	client *vcf.ClientWithResponses
+}
+
+func (e *EphemeralResourceCredentials) Metadata(_ context.Context, _ ephemeral.MetadataRequest, res *ephemeral.MetadataResponse) {
+	res.TypeName = "vcf_credentials"
+}
+
+func (e *EphemeralResourceCredentials) Configure(_ context.Context, req ephemeral.ConfigureRequest, res *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, err := getSddcManagerClient(req.ProviderData)
+	if err != nil {
+		res.Diagnostics.AddError("SDDC Manager is not configured", err.Error())
+		return
+	}
+	e.client = client.ApiClient
+}
+
+func (e *EphemeralResourceCredentials) Schema(_ context.Context, _ ephemeral.SchemaRequest, res *ephemeral.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Description: "Reads the credentials of the resources that are part of the SDDC deployment based on name, ip, type, domain or account type, without storing them in the plan or state",
+		Attributes: map[string]schema.Attribute{
+			"resource_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the resource",
+			},
+			"resource_ip": schema.StringAttribute{
+				Optional:    true,
+				Description: "The IP Address of the resource",
+			},
+			"resource_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The type of the resource. One among ESXI, VCENTER, PSC, NSX_MANAGER, NSX_CONTROLLER, NSXT_EDGE, NSXT_MANAGER, VRLI, VROPS, VRA, WSA, VRSLCM, VXRAIL_MANAGER, NSX_ALB, BACKUP",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(credentials.AllResourceTypes()...),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The domain in which context we do the credentials read.",
+			},
+			"account_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The type(s) of the account.One among USER, SYSTEM, SERVICE",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(credentials.AllAccountTypes()...),
+				},
+			},
+			"page": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The page of credentials that is returned as result. By default the credentials of all pages are returned.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of credentials retrieved per request. Default is 0 so all records are retrieved in one request",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"credentials": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of credentials read from the API",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID hash of the credential",
+						},
+						"user_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username of the account to which the credential belong",
+						},
+						"password": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The password of the account to which the credential belong",
+						},
+						"account_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "One among USER, SYSTEM, SERVICE",
+						},
+						"credential_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the credential. For example FTP, SSH, etc.",
+						},
+						"auto_rotate_frequency_days": schema.Int64Attribute{
+							Computed:    true,
+							Description: "After how many days the credentials will be auto rotated. One among 30, 60, 90",
+						},
+						"auto_rotate_next_schedule": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time of the next rotation",
+						},
+						"creation_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time when the credential is created",
+						},
+						"modification_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The last time the credentials are changed",
+						},
+						"resource": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Computed:    true,
+										Description: "The ID hash of the resource to which the credential belongs",
+									},
+									"domain": schema.StringAttribute{
+										Computed:    true,
+										Description: "The VCF domain to which the resource belongs",
+									},
+									"ip": schema.StringAttribute{
+										Computed:    true,
+										Description: "The ip address of the resource related to the credential",
+									},
+									"name": schema.StringAttribute{
+										Computed:    true,
+										Description: "The name of the resource as registered in SDDC Manager inventory",
+									},
+									"type": schema.StringAttribute{
+										Computed:    true,
+										Description: "The type of the resource.One among ESXI, VCENTER, PSC, NSX_MANAGER, NSX_CONTROLLER, NSXT_EDGE, NSXT_MANAGER, VRLI, VROPS, VRA, WSA, VRSLCM, VXRAIL_MANAGER, NSX_ALB, BACKUP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (e *EphemeralResourceCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, res *ephemeral.OpenResponse) {
+	var data EphemeralResourceCredentialsModel
+	ctx, span := tracing.StartSpan(ctx, "ephemeral.vcf_credentials.Open", tracing.AttributeResourceType.String("vcf_credentials"))
+	defer func() { endFrameworkSpan(span, res.Diagnostics, "") }()
+	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	creds, err := credentials.ReadFilteredCredentials(ctx, credentials.Filter{
+		ResourceName: data.ResourceName.ValueString(),
+		ResourceIp:   data.ResourceIp.ValueString(),
+		ResourceType: data.ResourceType.ValueString(),
+		DomainName:   data.DomainName.ValueString(),
+		AccountType:  data.AccountType.ValueString(),
+		Page:         int(data.Page.ValueInt64()),
+		PageSize:     int(data.PageSize.ValueInt64()),
+	}, e.client)
+	if err != nil {
+		res.Diagnostics.Append(newApiErrorDiagnostic(err))
+		return
+	}
+
+	data.Credentials = make([]CredentialModel, 0, len(creds))
+	for _, credential := range creds {
+		data.Credentials = append(data.Credentials, newCredentialModel(credential))
+	}
+	res.Diagnostics.Append(res.Result.Set(ctx, &data)...)
+}
+
+func newCredentialModel(credential vcf.Credential) CredentialModel {
+	model := CredentialModel{
+		Id:                      types.StringPointerValue(credential.Id),
+		UserName:                types.StringPointerValue(credential.Username),
+		Password:                types.StringPointerValue(credential.Password),
+		AccountType:             types.StringPointerValue(credential.AccountType),
+		CredentialType:          types.StringPointerValue(credential.CredentialType),
+		AutoRotateFrequencyDays: types.Int64Null(),
+		AutoRotateNextSchedule:  types.StringNull(),
+		CreationTime:            types.StringPointerValue(credential.CreationTimestamp),
+		ModificationTime:        types.StringPointerValue(credential.ModificationTimestamp),
+		Resource:                []CredentialResourceModel{},
+	}
+	if policy := credential.AutoRotatePolicy; policy != nil {
+		if policy.FrequencyInDays != nil {
+			model.AutoRotateFrequencyDays = types.Int64Value(int64(*policy.FrequencyInDays))
+		}
+		model.AutoRotateNextSchedule = types.StringPointerValue(policy.NextSchedule)
+	}
+	if resource := credential.Resource; resource != nil {
+		model.Resource = append(model.Resource, CredentialResourceModel{
+			Id:     types.StringValue(resource.ResourceId),
+			Domain: types.StringPointerValue(resource.DomainName),
+			Ip:     types.StringPointerValue(resource.ResourceIp),
+			Name:   types.StringValue(resource.ResourceName),
+			Type:   types.StringValue(resource.ResourceType),
+		})
+	}
+	return model
+}
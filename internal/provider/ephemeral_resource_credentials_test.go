@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func TestEphemeralResourceCredentialsOpen(t *testing.T) {
+	ctx := context.Background()
+	server, client := testSddcManagerClient(t)
+	server.AddCredential(vcf.Credential{
+		Username:    utils.ToStringPointer("root"),
+		Password:    utils.ToStringPointer("S@mpleL0ngP@ss123!"),
+		AccountType: utils.ToStringPointer("USER"),
+		Resource: &vcf.AuthenticatedResource{
+			ResourceId:   "host-1",
+			ResourceName: "esxi-1.vrack.vsphere.local",
+			ResourceType: "ESXI",
+		},
+	})
+	server.AddCredential(vcf.Credential{
+		Username: utils.ToStringPointer("admin"),
+		Password: utils.ToStringPointer("An0therL0ngP@ss123!"),
+		Resource: &vcf.AuthenticatedResource{
+			ResourceId:   "nsx-1",
+			ResourceName: "nsx-1.vrack.vsphere.local",
+			ResourceType: "NSXT_MANAGER",
+		},
+	})
+
+	e := &EphemeralResourceCredentials{}
+	e.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: &providerClients{sddcManager: client}}, &ephemeral.ConfigureResponse{})
+	schemaRes := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, schemaRes)
+	s := schemaRes.Schema
+
+	config := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, config.Set(ctx, &EphemeralResourceCredentialsModel{
+		ResourceName: types.StringValue("esxi-1.vrack.vsphere.local"),
+		ResourceIp:   types.StringNull(),
+		ResourceType: types.StringNull(),
+		DomainName:   types.StringNull(),
+		AccountType:  types.StringNull(),
+		Page:         types.Int64Null(),
+		PageSize:     types.Int64Null(),
+	}).HasError())
+
+	res := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	e.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: s, Raw: config.Raw}}, res)
+	require.False(t, res.Diagnostics.HasError(), res.Diagnostics)
+
+	var result EphemeralResourceCredentialsModel
+	require.False(t, res.Result.Get(ctx, &result).HasError())
+	if assert.Len(t, result.Credentials, 1) {
+		credential := result.Credentials[0]
+		assert.Equal(t, "root", credential.UserName.ValueString())
+		assert.Equal(t, "S@mpleL0ngP@ss123!", credential.Password.ValueString())
+		assert.True(t, credential.AutoRotateFrequencyDays.IsNull())
+		if assert.Len(t, credential.Resource, 1) {
+			assert.Equal(t, "host-1", credential.Resource[0].Id.ValueString())
+			assert.True(t, credential.Resource[0].Ip.IsNull())
+		}
+	}
+}
@@ -6,12 +6,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -22,6 +26,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credential_source"
 )
 
 type FrameworkProviderModel struct {
@@ -29,11 +34,44 @@ type FrameworkProviderModel struct {
 	SddcManagerPassword types.String `tfsdk:"sddc_manager_password"`
 	SddcManagerHost     types.String `tfsdk:"sddc_manager_host"`
 
+	CredentialProcess types.String `tfsdk:"credential_process"`
+	CredentialsFile   types.String `tfsdk:"credentials_file"`
+	Profile           types.String `tfsdk:"profile"`
+
+	SddcManagerApiKey      types.String `tfsdk:"sddc_manager_api_key"`
+	SddcManagerAccessToken types.String `tfsdk:"sddc_manager_access_token"`
+
 	InstallerUsername types.String `tfsdk:"installer_username"`
 	InstallerPassword types.String `tfsdk:"installer_password"`
 	InstallerHost     types.String `tfsdk:"installer_host"`
 
 	AllowUnverifiedTls types.Bool `tfsdk:"allow_unverified_tls"`
+
+	SddcManagerCaCertificate    types.String `tfsdk:"sddc_manager_ca_certificate"`
+	SddcManagerServerThumbprint types.String `tfsdk:"sddc_manager_server_thumbprint"`
+	InstallerCaCertificate      types.String `tfsdk:"installer_ca_certificate"`
+	InstallerServerThumbprint   types.String `tfsdk:"installer_server_thumbprint"`
+
+	CancelTasksOnInterrupt types.Bool `tfsdk:"cancel_tasks_on_interrupt"`
+
+	MaxRetries      types.Int64 `tfsdk:"max_retries"`
+	RetryMaxBackoff types.Int64 `tfsdk:"retry_max_backoff"`
+
+	ProxyUrl              types.String `tfsdk:"proxy_url"`
+	ConnectTimeout        types.Int64  `tfsdk:"connect_timeout"`
+	RequestTimeout        types.Int64  `tfsdk:"request_timeout"`
+	KeepAlive             types.Int64  `tfsdk:"keep_alive"`
+	IdleConnectionTimeout types.Int64  `tfsdk:"idle_connection_timeout"`
+
+	MaxRequestsPerSecond  types.Int64 `tfsdk:"max_requests_per_second"`
+	MaxRequestBurst       types.Int64 `tfsdk:"max_request_burst"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	InventoryCache types.Bool `tfsdk:"inventory_cache"`
+
+	DryRun types.Bool `tfsdk:"dry_run"`
+
+	AuditLogPath types.String `tfsdk:"audit_log_path"`
 }
 
 type FrameworkProvider struct {
@@ -55,7 +93,11 @@ func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req prov
 				Optional:    true,
 				Description: "The username to authenticate to the SDDC Manager instance.",
 				Validators: []validator.String{
-					getSddcManagerConflictsValidator(),
+					stringvalidator.ConflictsWith(
+						path.Expressions{
+							path.MatchRoot("sddc_manager_api_key"),
+							path.MatchRoot("sddc_manager_access_token"),
+						}...),
 					stringvalidator.AlsoRequires(
 						path.Expressions{
 							path.MatchRoot("sddc_manager_password"),
@@ -67,7 +109,11 @@ func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req prov
 				Optional:    true,
 				Description: "The password to authenticate to the SDDC Manager instance.",
 				Validators: []validator.String{
-					getSddcManagerConflictsValidator(),
+					stringvalidator.ConflictsWith(
+						path.Expressions{
+							path.MatchRoot("sddc_manager_api_key"),
+							path.MatchRoot("sddc_manager_access_token"),
+						}...),
 					stringvalidator.AlsoRequires(
 						path.Expressions{
 							path.MatchRoot("sddc_manager_username"),
@@ -75,23 +121,43 @@ func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req prov
 						}...),
 				},
 			},
-			"sddc_manager_host": schema.StringAttribute{
+			"sddc_manager_api_key": schema.StringAttribute{
 				Optional:    true,
-				Description: "The fully qualified domain name or IP address of the SDDC Manager instance.",
+				Sensitive:   true,
+				Description: "The API key of a service account to authenticate to the SDDC Manager instance, instead of a username and password. Can also be set with the VCF_API_KEY environment variable.",
 				Validators: []validator.String{
-					getSddcManagerConflictsValidator(),
-					stringvalidator.AlsoRequires(
-						path.Expressions{
-							path.MatchRoot("sddc_manager_username"),
-							path.MatchRoot("sddc_manager_password"),
-						}...),
+					stringvalidator.ConflictsWith(path.MatchRoot("sddc_manager_access_token")),
+					stringvalidator.AlsoRequires(path.MatchRoot("sddc_manager_host")),
+				},
+			},
+			"sddc_manager_access_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "An access token issued by the SDDC Manager instance, used instead of logging in. The token cannot be renewed, it has to be valid for the whole run. Can also be set with the VCF_ACCESS_TOKEN environment variable.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("sddc_manager_host")),
 				},
 			},
+			"credential_process": schema.StringAttribute{
+				Optional:    true,
+				Description: "A command which prints the credentials of the provider and other secrets as a JSON object, e.g. to retrieve them from a vault. Credentials set in the provider configuration take precedence. Can also be set with the VCF_CREDENTIAL_PROCESS environment variable.",
+			},
+			"credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a credentials file with named profiles of credentials and other secrets. Default is ~/.vcf/credentials if a profile is set. Can also be set with the VCF_CREDENTIALS_FILE environment variable.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "The profile of the credentials file to use. Default is \"default\" if a credentials file is set. Can also be set with the VCF_PROFILE environment variable.",
+			},
+			"sddc_manager_host": schema.StringAttribute{
+				Optional:    true,
+				Description: "The fully qualified domain name or IP address of the SDDC Manager instance.",
+			},
 			"installer_username": schema.StringAttribute{
 				Optional:    true,
 				Description: "The username to authenticate to the installer.",
 				Validators: []validator.String{
-					getCloudBuilderConflictsValidator(),
 					stringvalidator.AlsoRequires(
 						path.Expressions{
 							path.MatchRoot("installer_password"),
@@ -103,7 +169,6 @@ func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req prov
 				Optional:    true,
 				Description: "The password to authenticate to the installer.",
 				Validators: []validator.String{
-					getCloudBuilderConflictsValidator(),
 					stringvalidator.AlsoRequires(
 						path.Expressions{
 							path.MatchRoot("installer_username"),
@@ -114,19 +179,92 @@ func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req prov
 			"installer_host": schema.StringAttribute{
 				Optional:    true,
 				Description: "The fully qualified domain name or IP address of the installer.",
-				Validators: []validator.String{
-					getCloudBuilderConflictsValidator(),
-					stringvalidator.AlsoRequires(
-						path.Expressions{
-							path.MatchRoot("installer_username"),
-							path.MatchRoot("installer_password"),
-						}...),
-				},
 			},
 			"allow_unverified_tls": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Allow unverified TLS certificates.",
 			},
+			"sddc_manager_ca_certificate": schema.StringAttribute{
+				Optional:    true,
+				Description: "The PEM encoded certificate, or the path of a PEM file, of the CA which signed the certificate of the SDDC Manager. It is trusted in addition to the system CAs.",
+			},
+			"sddc_manager_server_thumbprint": schema.StringAttribute{
+				Optional:    true,
+				Description: "The SHA-256 thumbprint of the certificate of the SDDC Manager. If set, the SDDC Manager is trusted if and only if its certificate matches the thumbprint, which also works for self-signed certificates.",
+			},
+			"installer_ca_certificate": schema.StringAttribute{
+				Optional:    true,
+				Description: "The PEM encoded certificate, or the path of a PEM file, of the CA which signed the certificate of the installer. It is trusted in addition to the system CAs.",
+			},
+			"installer_server_thumbprint": schema.StringAttribute{
+				Optional:    true,
+				Description: "The SHA-256 thumbprint of the certificate of the installer. If set, the installer is trusted if and only if its certificate matches the thumbprint, which also works for self-signed certificates.",
+			},
+			"cancel_tasks_on_interrupt": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Cancel a running SDDC Manager task when waiting for it is interrupted, e.g. because the resource timeout has expired. By default the task is left running and can be tracked in the SDDC Manager UI.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of times a read or login request that failed with a transient error, such as a connection reset or a 429, 502 or 503 response, is retried. Set to 0 to disable retries. Default is 4.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_max_backoff": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum delay in seconds between two retries of a failed request. Default is 30.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the HTTP proxy to reach the SDDC Manager or installer through, e.g. http://proxy.example.com:3128. Hosts in the NO_PROXY environment variable are reached directly. By default the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables.",
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests per second sent to the SDDC Manager or installer. Requests wait until they are allowed. Set to 0 to disable the limit. Default is 10.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_request_burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of requests which may be sent to the SDDC Manager or installer at once before max_requests_per_second applies. Default is 1, which spreads the requests evenly.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests in flight to the SDDC Manager or installer at the same time. Set to 0 to disable the limit. Default is 6.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"inventory_cache": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Cache the domains, clusters, hosts, network pools and edge clusters read from the SDDC Manager for the run, so that a refresh retrieves every list and every element once. Each response is cached by its own URL. The cache is cleared whenever the provider changes the inventory or a task finishes. Set to false if the inventory is changed outside of Terraform during a run. Default is true.",
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the create, update and delete operations of the resources build their spec and only run the validation of SDDC Manager or the VCF Installer for it, reporting the results as diagnostics, and never start a workflow. Operations which cannot be validated fail without any change. Default is false.",
+			},
+			"audit_log_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a file to which every request sent to the SDDC Manager or installer and its response are appended as JSON lines, with passwords, secrets, tokens and API keys redacted. Can also be set with the VCF_AUDIT_LOG_PATH environment variable.",
+			},
+			"connect_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum time in seconds to establish a connection, including the TLS handshake. Set to 0 to disable the limit. Default is 30.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum time in seconds a request takes, from sending it until its response has been read. Set to 0 to disable the limit. Default is 300.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"keep_alive": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The interval in seconds between TCP keep-alive probes of a connection. Set to 0 to disable keep-alive and use a new connection for every request. Default is 30.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"idle_connection_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The time in seconds an idle connection is kept open for reuse. Default is 90.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
 		},
 	}
 }
@@ -149,75 +287,175 @@ func (frameworkProvider *FrameworkProvider) DataSources(ctx context.Context) []f
 	}
 }
 
+func (frameworkProvider *FrameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource { return &EphemeralResourceCredentials{} },
+	}
+}
+
 func (frameworkProvider *FrameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, res *provider.ConfigureResponse) {
 	var data FrameworkProviderModel
 
 	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	sddcManagerUsername := getAttributeValue(data.SddcManagerUsername.ValueString(), constants.VcfTestUsername).(string)
+	credentials, err := loadCredentials(ctx, credential_source.Source{
+		CredentialProcess: getStringOrEnv(data.CredentialProcess, constants.VcfCredentialProcess),
+		CredentialsFile:   getStringOrEnv(data.CredentialsFile, constants.VcfCredentialsFile),
+		Profile:           getStringOrEnv(data.Profile, constants.VcfProfile),
+	})
+	if err != nil {
+		res.Diagnostics.Append(newApiErrorDiagnostic(err))
+		return
+	}
+	getCredential := func(value types.String, envVar, fallback string) string {
+		if credential := getStringOrEnv(value, envVar); credential != "" {
+			return credential
+		}
+		return fallback
+	}
+
+	clientOptions := getClientOptions(data)
+	sddcManagerUsername := getCredential(data.SddcManagerUsername, constants.VcfTestUsername, credentials.SddcManagerUsername)
+	sddcManagerPassword := getCredential(data.SddcManagerPassword, constants.VcfTestPassword, credentials.SddcManagerPassword)
+	sddcManagerApiKey := getCredential(data.SddcManagerApiKey, constants.VcfApiKey, credentials.SddcManagerApiKey)
+	sddcManagerAccessToken := getCredential(data.SddcManagerAccessToken, constants.VcfAccessToken, credentials.SddcManagerAccessToken)
+	installerUsername := getCredential(data.InstallerUsername, constants.InstallerTestUsername, credentials.InstallerUsername)
+	installerPassword := getCredential(data.InstallerPassword, constants.InstallerTestPassword, credentials.InstallerPassword)
+	isSddcManagerSet := sddcManagerUsername != "" || sddcManagerApiKey != "" || sddcManagerAccessToken != ""
+	clients := &providerClients{credentials: credentials, dryRun: data.DryRun.ValueBool()}
 
-	if sddcManagerUsername != "" {
-		// Connect to SDDC Manager
-		client := api_client.NewSddcManagerClient(
+	if !isSddcManagerSet && installerUsername == "" {
+		res.Diagnostics.AddError("Either SDDC Manager or Installer configuration must be provided", "")
+		return
+	}
+
+	if isSddcManagerSet {
+		hostName := getStringOrEnv(data.SddcManagerHost, constants.VcfTestUrl)
+		if hostName == "" || (sddcManagerUsername != "" && sddcManagerPassword == "") {
+			res.Diagnostics.AddError("SDDC Manager host and either username and password, API key or access token must be provided", "")
+			return
+		}
+		sddcManagerOptions := append([]api_client.ClientOption{
+			api_client.WithApiKey(sddcManagerApiKey),
+			api_client.WithAccessToken(sddcManagerAccessToken),
+			api_client.WithCaCertificate(getStringOrEnv(data.SddcManagerCaCertificate, constants.VcfCaCertificate)),
+			api_client.WithServerThumbprint(getStringOrEnv(data.SddcManagerServerThumbprint, constants.VcfServerThumbprint)),
+		}, clientOptions...)
+
+		// Shares the client of the SDK v2 provider, which is configured with the same settings
+		client, err := api_client.GetSddcManagerClient(
 			sddcManagerUsername,
-			getAttributeValue(data.SddcManagerPassword.ValueString(), constants.VcfTestPassword).(string),
-			getAttributeValue(data.SddcManagerHost.ValueString(), constants.VcfTestUrl).(string),
+			sddcManagerPassword,
+			hostName,
 			version.ProviderVersion,
-			getAttributeValue(data.AllowUnverifiedTls.ValueBool(), constants.VcfTestAllowUnverifiedTls).(bool),
+			getBoolOrEnv(data.AllowUnverifiedTls, constants.VcfTestAllowUnverifiedTls),
+			sddcManagerOptions...,
 		)
 
-		if err := client.Connect(); err != nil {
-			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to connect to the SDDC Manager", err.Error()))
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to configure the SDDC Manager client", err.Error()))
+			return
 		}
 
 		frameworkProvider.SddcManagerClient = client
-		res.ResourceData = client
-		res.DataSourceData = client
-	} else {
-		// Connect to installer
-		client := api_client.NewInstallerClient(
-			getAttributeValue(data.InstallerUsername.ValueString(), constants.InstallerTestUsername).(string),
-			getAttributeValue(data.InstallerPassword.ValueString(), constants.InstallerTestPassword).(string),
-			getAttributeValue(data.InstallerHost.ValueString(), constants.InstallerTestUrl).(string),
-			getAttributeValue(data.AllowUnverifiedTls.ValueBool(), constants.VcfTestAllowUnverifiedTls).(bool),
+		clients.sddcManager = client
+	}
+
+	if installerUsername != "" {
+		hostName := getStringOrEnv(data.InstallerHost, constants.InstallerTestUrl)
+		if installerPassword == "" || hostName == "" {
+			res.Diagnostics.AddError("Installer username, password, and host must be provided", "")
+			return
+		}
+		installerOptions := append([]api_client.ClientOption{
+			api_client.WithCaCertificate(getStringOrEnv(data.InstallerCaCertificate, constants.InstallerCaCertificate)),
+			api_client.WithServerThumbprint(getStringOrEnv(data.InstallerServerThumbprint, constants.InstallerServerThumbprint)),
+		}, clientOptions...)
+		client, err := api_client.GetInstallerClient(
+			installerUsername,
+			installerPassword,
+			hostName,
+			getBoolOrEnv(data.AllowUnverifiedTls, constants.VcfTestAllowUnverifiedTls),
+			installerOptions...,
 		)
 
-		if err := client.Connect(); err != nil {
-			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to connect to the VCF Installer", err.Error()))
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to configure the VCF Installer client", err.Error()))
+			return
 		}
 
 		frameworkProvider.InstallerClient = client
-		res.ResourceData = client
-		res.DataSourceData = client
+		clients.installer = client
 	}
+
+	res.ResourceData = clients
+	res.DataSourceData = clients
+	res.EphemeralResourceData = clients
 }
 
-func getAttributeValue[T string | bool](data T, envVar string) interface{} {
-	if envVal := os.Getenv(envVar); envVal != "" {
-		if val, err := strconv.ParseBool(envVal); err == nil {
-			return val
-		}
+// getClientOptions returns the connection settings of the provider, falling back to the defaults
+// of the API clients for the settings which are not configured.
+func getClientOptions(data FrameworkProviderModel) []api_client.ClientOption {
+	return []api_client.ClientOption{
+		api_client.WithRetry(getInt(data.MaxRetries, api_client.DefaultMaxRetries), getSeconds(data.RetryMaxBackoff, api_client.DefaultRetryMaxBackoff)),
+		api_client.WithCancelTasksOnInterrupt(data.CancelTasksOnInterrupt.ValueBool()),
+		api_client.WithProxyUrl(data.ProxyUrl.ValueString()),
+		api_client.WithRequestLimits(
+			getInt(data.MaxRequestsPerSecond, api_client.DefaultMaxRequestsPerSecond),
+			getInt(data.MaxConcurrentRequests, api_client.DefaultMaxConcurrentRequests)),
+		api_client.WithRequestBurst(getInt(data.MaxRequestBurst, api_client.DefaultMaxRequestBurst)),
+		api_client.WithInventoryCache(data.InventoryCache.IsNull() || data.InventoryCache.ValueBool()),
+		api_client.WithAuditLog(getStringOrEnv(data.AuditLogPath, constants.VcfAuditLogPath)),
+		api_client.WithTimeouts(
+			getSeconds(data.ConnectTimeout, api_client.DefaultConnectTimeout),
+			getSeconds(data.RequestTimeout, api_client.DefaultRequestTimeout)),
+		api_client.WithKeepAlive(
+			getSeconds(data.KeepAlive, api_client.DefaultKeepAlive),
+			getSeconds(data.IdleConnectionTimeout, api_client.DefaultIdleConnectionTimeout)),
+	}
+}
+
+// getInt returns the value of an attribute, or the default if it is not set.
+func getInt(value types.Int64, defaultValue int) int {
+	if value.IsNull() {
+		return defaultValue
+	}
+	return int(value.ValueInt64())
+}
 
-		return envVal
+// getSeconds returns the duration of an attribute in seconds, or the default if it is not set.
+func getSeconds(seconds types.Int64, defaultValue time.Duration) time.Duration {
+	if seconds.IsNull() {
+		return defaultValue
 	}
+	return time.Duration(seconds.ValueInt64()) * time.Second
+}
 
-	return data
+// getStringOrEnv returns the value of a string attribute, or the value of the environment
+// variable if the attribute is not set, like the EnvDefaultFunc of the SDK v2 provider.
+func getStringOrEnv(value types.String, envVar string) string {
+	if value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return os.Getenv(envVar)
 }
 
-func getSddcManagerConflictsValidator() validator.String {
-	return stringvalidator.ConflictsWith(
-		path.Expressions{
-			path.MatchRoot("installer_username"),
-			path.MatchRoot("installer_password"),
-			path.MatchRoot("installer_host"),
-		}...)
+// getBoolOrEnv returns the value of a bool attribute, or the value of the environment variable if the
+// attribute is not set, like getStringOrEnv.
+func getBoolOrEnv(value types.Bool, envVar string) bool {
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+	result, _ := strconv.ParseBool(os.Getenv(envVar))
+	return result
 }
 
-func getCloudBuilderConflictsValidator() validator.String {
-	return stringvalidator.ConflictsWith(
-		path.Expressions{
-			path.MatchRoot("sddc_manager_username"),
-			path.MatchRoot("sddc_manager_password"),
-			path.MatchRoot("sddc_manager_host"),
-		}...)
+// newApiErrorDiagnostic converts an error to a diagnostic. Errors returned by the SDDC Manager
+// API keep their remediation, causes and reference token in the detail of the diagnostic.
+func newApiErrorDiagnostic(err error) diag.Diagnostic {
+	var apiErr *api_client.ApiError
+	if errors.As(err, &apiErr) {
+		return diag.NewErrorDiagnostic(err.Error(), apiErr.Detail())
+	}
+	return diag.NewErrorDiagnostic(err.Error(), "")
 }
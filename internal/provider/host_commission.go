@@ -0,0 +1,149 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+// hostCommissionWindow is how long the commissioning of a host waits for further hosts to be
+// created, so that they are all commissioned by a single task. Terraform creates the vcf_host
+// resources of a configuration in parallel, so they arrive within a short time of each other.
+var hostCommissionWindow = 5 * time.Second
+
+var (
+	hostCommissionsMu sync.Mutex
+	// pendingHostCommissions holds the batch of hosts of each SDDC Manager which is waiting to
+	// be commissioned.
+	pendingHostCommissions = make(map[*api_client.SddcManagerClient]*hostCommissionBatch)
+)
+
+// hostCommissionBatch is a group of hosts which are commissioned by a single task.
+type hostCommissionBatch struct {
+	requests  []*hostCommissionRequest
+	submitted bool
+	done      chan struct{}
+}
+
+// hostCommissionRequest is the commissioning of a single host within a batch.
+type hostCommissionRequest struct {
+	spec      vcf.HostCommissionSpec
+	withdrawn bool
+
+	taskId string
+	err    error
+}
+
+// commissionHost commissions a host together with the other hosts of the same SDDC Manager which
+// are commissioned within hostCommissionWindow, and returns the ID of the task which commissions
+// them. The task is shared by all hosts of the batch, so it must not be cancelled by any of them.
+//
+// If SDDC Manager rejects the batch, each host is commissioned on its own, so that a host which
+// cannot be commissioned does not fail the others.
+func commissionHost(ctx context.Context, vcfClient *api_client.SddcManagerClient, spec vcf.HostCommissionSpec) (string, error) {
+	request := &hostCommissionRequest{spec: spec}
+
+	hostCommissionsMu.Lock()
+	batch, ok := pendingHostCommissions[vcfClient]
+	if !ok {
+		batch = &hostCommissionBatch{done: make(chan struct{})}
+		pendingHostCommissions[vcfClient] = batch
+		// The batch outlives the resource which has started it
+		batchCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(hostCommissionWindow, func() {
+			batch.submit(batchCtx, vcfClient)
+		})
+	}
+	batch.requests = append(batch.requests, request)
+	hostCommissionsMu.Unlock()
+
+	select {
+	case <-batch.done:
+		return request.taskId, request.err
+	case <-ctx.Done():
+	}
+
+	hostCommissionsMu.Lock()
+	submitted := batch.submitted
+	if !submitted {
+		request.withdrawn = true
+	}
+	hostCommissionsMu.Unlock()
+	if !submitted {
+		return "", ctx.Err()
+	}
+
+	// The host is being commissioned, the task needs to be recorded
+	<-batch.done
+	return request.taskId, request.err
+}
+
+// submit commissions the hosts of the batch which have not been withdrawn.
+func (batch *hostCommissionBatch) submit(ctx context.Context, vcfClient *api_client.SddcManagerClient) {
+	defer close(batch.done)
+
+	hostCommissionsMu.Lock()
+	if pendingHostCommissions[vcfClient] == batch {
+		delete(pendingHostCommissions, vcfClient)
+	}
+	batch.submitted = true
+	var requests []*hostCommissionRequest
+	for _, request := range batch.requests {
+		if !request.withdrawn {
+			requests = append(requests, request)
+		}
+	}
+	hostCommissionsMu.Unlock()
+
+	if len(requests) == 0 {
+		return
+	}
+
+	specs := make([]vcf.HostCommissionSpec, len(requests))
+	for i, request := range requests {
+		specs[i] = request.spec
+	}
+	taskId, err := submitHostCommission(ctx, vcfClient, specs)
+	if err == nil || len(requests) == 1 {
+		for _, request := range requests {
+			request.taskId, request.err = taskId, err
+		}
+		return
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Commissioning %d hosts together has been rejected, commissioning them one by one: %s",
+		len(requests), err))
+	for _, request := range requests {
+		request.taskId, request.err = submitHostCommission(ctx, vcfClient, []vcf.HostCommissionSpec{request.spec})
+	}
+}
+
+func submitHostCommission(ctx context.Context, vcfClient *api_client.SddcManagerClient, specs []vcf.HostCommissionSpec) (string, error) {
+	accepted, err := vcfClient.ApiClient.CommissionHostsWithResponse(ctx, specs)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		return "", err
+	}
+	task, vcfErr := api_client.GetResponseAs[vcf.Task](accepted)
+	if vcfErr != nil {
+		api_client.LogError(vcfErr, ctx)
+		return "", api_client.NewApiError(vcfErr)
+	}
+
+	fqdns := make([]string, len(specs))
+	for i, spec := range specs {
+		fqdns[i] = spec.Fqdn
+	}
+	tflog.Info(ctx, fmt.Sprintf("Commissioning of hosts %v initiated, task id = %s", fqdns, *task.Id))
+	return *task.Id, nil
+}
@@ -0,0 +1,156 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+// operationKey identifies a domain, a cluster or an edge cluster of an SDDC Manager on which SDDC
+// Manager runs a single workflow at a time.
+type operationKey struct {
+	client *api_client.SddcManagerClient
+	kind   string
+	id     string
+}
+
+func (key operationKey) String() string {
+	return fmt.Sprintf("%s %s", key.kind, key.id)
+}
+
+var (
+	operationQueuesMu sync.Mutex
+	// operationQueues holds a semaphore for every domain and cluster, full while an operation
+	// of the provider runs on it. Operations waiting for the semaphore are queued.
+	operationQueues = make(map[operationKey]chan struct{})
+)
+
+// heldOperationsKey is the key of the context value which holds the operation keys locked by
+// the operation of the context, so that nested operations on the same domain do not wait for
+// the outer operation.
+type heldOperationsKey struct{}
+
+// lockDomain waits until no other operation of the provider runs on a domain, and returns a
+// context in which the domain is locked and the function which unlocks it. Mutations of the
+// same domain run one at a time, mutations of different domains run in parallel.
+func lockDomain(ctx context.Context, vcfClient *api_client.SddcManagerClient, domainId string) (context.Context, func(), error) {
+	return lockOperations(ctx, operationKey{client: vcfClient, kind: "domain", id: domainId})
+}
+
+// lockCluster waits until no other operation of the provider runs on a cluster or on its domain,
+// and returns a context in which both are locked and the function which unlocks them.
+func lockCluster(ctx context.Context, vcfClient *api_client.SddcManagerClient, clusterId string) (context.Context, func(), error) {
+	return lockOperations(ctx, clusterKeys(ctx, vcfClient, clusterId)...)
+}
+
+// lockEdgeCluster waits until no other operation of the provider runs on an edge cluster, on the
+// clusters of its edge nodes or on their domains, and returns a context in which they are locked
+// and the function which unlocks them. The ID of the edge cluster is empty while it is created.
+func lockEdgeCluster(ctx context.Context, vcfClient *api_client.SddcManagerClient, edgeClusterId string,
+	clusterIds []string) (context.Context, func(), error) {
+	keys := clusterKeys(ctx, vcfClient, clusterIds...)
+	if edgeClusterId != "" {
+		keys = append(keys, operationKey{client: vcfClient, kind: "edge cluster", id: edgeClusterId})
+	}
+	return lockOperations(ctx, keys...)
+}
+
+// clusterKeys returns the operation keys of clusters and of their domains. Domains are always
+// locked before clusters and both in the order of their IDs, so that operations do not deadlock.
+// A cluster whose domain cannot be retrieved is locked alone.
+func clusterKeys(ctx context.Context, vcfClient *api_client.SddcManagerClient, clusterIds ...string) []operationKey {
+	var domainIds []string
+	clusterIds = slices.Compact(slices.Sorted(slices.Values(clusterIds)))
+	for _, clusterId := range clusterIds {
+		if domainId := getClusterDomainId(ctx, vcfClient, clusterId); domainId != "" {
+			domainIds = append(domainIds, domainId)
+		}
+	}
+	domainIds = slices.Compact(slices.Sorted(slices.Values(domainIds)))
+
+	keys := make([]operationKey, 0, len(domainIds)+len(clusterIds))
+	for _, domainId := range domainIds {
+		keys = append(keys, operationKey{client: vcfClient, kind: "domain", id: domainId})
+	}
+	for _, clusterId := range clusterIds {
+		keys = append(keys, operationKey{client: vcfClient, kind: "cluster", id: clusterId})
+	}
+	return keys
+}
+
+// getClusterDomainId returns the ID of the domain of a cluster, or an empty string if it cannot
+// be retrieved. The cluster alone is locked in that case.
+func getClusterDomainId(ctx context.Context, vcfClient *api_client.SddcManagerClient, clusterId string) string {
+	res, err := vcfClient.ApiClient.GetClusterWithResponse(ctx, clusterId)
+	if err != nil {
+		return ""
+	}
+	clusterObj, vcfErr := api_client.GetResponseAs[vcf.Cluster](res)
+	if vcfErr != nil || clusterObj.Domain == nil {
+		return ""
+	}
+	return clusterObj.Domain.Id
+}
+
+// lockOperations queues the operation behind the other operations of the provider on the given
+// keys. Operations in dry-run mode start no workflow, so they neither queue nor wait.
+func lockOperations(ctx context.Context, keys ...operationKey) (context.Context, func(), error) {
+	if isDryRun(ctx) {
+		return ctx, func() {}, nil
+	}
+
+	held, _ := ctx.Value(heldOperationsKey{}).(map[operationKey]bool)
+	locked := make(map[operationKey]bool, len(held)+len(keys))
+	for key := range held {
+		locked[key] = true
+	}
+
+	var unlocks []func()
+	unlock := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	for _, key := range keys {
+		if locked[key] {
+			continue
+		}
+		queue := getOperationQueue(key)
+		select {
+		case queue <- struct{}{}:
+		default:
+			tflog.Info(ctx, fmt.Sprintf("Waiting for the running operation on %s to complete", key))
+			select {
+			case queue <- struct{}{}:
+			case <-ctx.Done():
+				unlock()
+				return ctx, nil, fmt.Errorf("stopped waiting for the running operation on %s: %w", key, ctx.Err())
+			}
+		}
+		unlocks = append(unlocks, func() { <-queue })
+		locked[key] = true
+	}
+	return context.WithValue(ctx, heldOperationsKey{}, locked), unlock, nil
+}
+
+func getOperationQueue(key operationKey) chan struct{} {
+	operationQueuesMu.Lock()
+	defer operationQueuesMu.Unlock()
+
+	queue, ok := operationQueues[key]
+	if !ok {
+		queue = make(chan struct{}, 1)
+		operationQueues[key] = queue
+	}
+	return queue
+}
@@ -0,0 +1,99 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+// lockedWithin reports whether lock returns within the given time.
+func lockedWithin(timeout time.Duration, lock func(ctx context.Context) (context.Context, func(), error)) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, unlock, err := lock(ctx)
+	if err != nil {
+		return false
+	}
+	unlock()
+	return true
+}
+
+func TestLockDomain(t *testing.T) {
+	_, client := testSddcManagerClient(t)
+
+	ctx, unlock, err := lockDomain(context.Background(), client, "domain-1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, lockedWithin(50*time.Millisecond, func(ctx context.Context) (context.Context, func(), error) {
+		return lockDomain(ctx, client, "domain-1")
+	}), "expected the operations on the same domain to wait")
+	assert.True(t, lockedWithin(time.Second, func(context.Context) (context.Context, func(), error) {
+		return lockDomain(context.Background(), client, "domain-2")
+	}), "expected the operations on other domains to run in parallel")
+	assert.True(t, lockedWithin(time.Second, func(context.Context) (context.Context, func(), error) {
+		return lockDomain(ctx, client, "domain-1")
+	}), "expected nested operations on the same domain not to wait")
+
+	unlock()
+	assert.True(t, lockedWithin(time.Second, func(ctx context.Context) (context.Context, func(), error) {
+		return lockDomain(ctx, client, "domain-1")
+	}), "expected the domain to be unlocked")
+}
+
+func TestLockCluster(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{Name: utils.ToStringPointer("wld-1")})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("cluster-1")})
+	otherClusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("cluster-2")})
+
+	_, unlock, err := lockCluster(context.Background(), client, clusterId)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, lockedWithin(50*time.Millisecond, func(ctx context.Context) (context.Context, func(), error) {
+		return lockDomain(ctx, client, domainId)
+	}), "expected the operations on the domain of the cluster to wait")
+	assert.False(t, lockedWithin(50*time.Millisecond, func(ctx context.Context) (context.Context, func(), error) {
+		return lockCluster(ctx, client, otherClusterId)
+	}), "expected the operations on the other clusters of the domain to wait")
+
+	unlock()
+	assert.True(t, lockedWithin(time.Second, func(ctx context.Context) (context.Context, func(), error) {
+		return lockCluster(ctx, client, otherClusterId)
+	}), "expected the cluster to be unlocked")
+}
+
+func TestLockEdgeCluster(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{Name: utils.ToStringPointer("wld-1")})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("cluster-1")})
+
+	_, unlock, err := lockEdgeCluster(context.Background(), client, "edge-cluster-1", []string{clusterId, clusterId})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, lockedWithin(50*time.Millisecond, func(ctx context.Context) (context.Context, func(), error) {
+		return lockCluster(ctx, client, clusterId)
+	}), "expected the operations on the clusters of the edge nodes to wait")
+	assert.False(t, lockedWithin(50*time.Millisecond, func(ctx context.Context) (context.Context, func(), error) {
+		return lockEdgeCluster(ctx, client, "edge-cluster-1", nil)
+	}), "expected the operations on the edge cluster to wait")
+
+	unlock()
+	assert.True(t, lockedWithin(time.Second, func(ctx context.Context) (context.Context, func(), error) {
+		return lockDomain(ctx, client, domainId)
+	}), "expected the domain to be unlocked")
+}
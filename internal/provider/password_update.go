@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+// accountPassword is a password attribute of a resource which is the password of an account of a
+// component managed by SDDC Manager. The path of an attribute nested in a block joins the name of
+// the block, the index of the element and the name of the attribute with dots, e.g.
+// "sso.0.domain_password".
+type accountPassword struct {
+	path           string
+	resourceType   string
+	resourceName   string
+	credentialType string
+	username       string
+}
+
+// hasPasswordChanges reports whether the version of the write-only counterpart of any of the
+// passwords has changed. A change of the password attribute itself is not applied to the account.
+func hasPasswordChanges(data *schema.ResourceData, passwords ...accountPassword) bool {
+	for _, password := range passwords {
+		if data.HasChange(password.path + utils.WriteOnlyVersionSuffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateAccountPasswords sets the passwords whose write-only version has changed on their
+// accounts through SDDC Manager and waits for each update to complete. The new password is the
+// write-only value, or the value of the password attribute if the write-only value is not set.
+func updateAccountPasswords(ctx context.Context, data *schema.ResourceData, vcfClient *api_client.SddcManagerClient,
+	passwords ...accountPassword) error {
+	for _, password := range passwords {
+		if !hasPasswordChanges(data, password) {
+			continue
+		}
+		value := secretAt(data, password.path)
+		if value == "" {
+			return fmt.Errorf("%s or %s%s is required to update the password of %s on %s",
+				password.path, password.path, utils.WriteOnlySuffix, password.username, password.resourceName)
+		}
+		err := credentials.UpdateResourcePasswords(ctx, vcfClient, password.resourceType, password.resourceName,
+			credentials.PasswordUpdate{CredentialType: password.credentialType, Username: password.username, Password: value})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretAt returns the value of the secret attribute with the given path, or the value of its
+// write-only counterpart if the attribute is not set.
+func secretAt(data *schema.ResourceData, path string) string {
+	names := strings.Split(path, ".")
+	if len(names) != 3 {
+		return utils.GetSecret(data, path)
+	}
+	blocks, _ := utils.GetWithWriteOnly(data, names[0]).([]interface{})
+	index, err := strconv.Atoi(names[1])
+	if err != nil || index >= len(blocks) {
+		return ""
+	}
+	block, _ := blocks[index].(map[string]interface{})
+	return utils.GetBlockSecret(block, names[2])
+}
@@ -0,0 +1,119 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func TestUpdateAccountPasswords_edgeCluster(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	edgeCluster := &vcf.EdgeCluster{EdgeNodes: &[]vcf.EdgeNodeReference{
+		{Id: "edge-node-1", HostName: "edge-1.vrack.vsphere.local"},
+		{Id: "edge-node-2", HostName: "edge-2.vrack.vsphere.local"},
+	}}
+	for _, node := range *edgeCluster.EdgeNodes {
+		for _, username := range []string{"root", "admin"} {
+			server.AddCredential(vcf.Credential{
+				Username: utils.ToStringPointer(username),
+				Password: utils.ToStringPointer("S@mpleL0ngP@ss123!"),
+				Resource: &vcf.AuthenticatedResource{ResourceId: node.Id, ResourceName: node.HostName, ResourceType: "NSXT_EDGE"},
+			})
+		}
+	}
+
+	data := schema.TestResourceDataRaw(t, ResourceEdgeCluster().Schema, map[string]interface{}{
+		"name":                     "edge-cluster-1",
+		"root_password_wo":         "An0therL0ngP@ss123!",
+		"root_password_wo_version": 2,
+	})
+	data.SetId("edge-cluster-1")
+
+	err := updateAccountPasswords(context.Background(), data, client, edgeClusterPasswords(edgeCluster)...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.RequestCount(http.MethodPatch, "/v1/credentials"))
+	for _, node := range *edgeCluster.EdgeNodes {
+		root, _ := server.Credential(node.HostName, "root")
+		assert.Equal(t, "An0therL0ngP@ss123!", *root.Password, node.HostName)
+		admin, _ := server.Credential(node.HostName, "admin")
+		assert.Equal(t, "S@mpleL0ngP@ss123!", *admin.Password, node.HostName)
+	}
+}
+
+func TestUpdateAccountPasswords_domain(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	server.AddCredential(vcf.Credential{
+		Username: utils.ToStringPointer("administrator@vsphere.local"),
+		Password: utils.ToStringPointer("S@mpleL0ngP@ss123!"),
+		Resource: &vcf.AuthenticatedResource{ResourceId: "psc-1", ResourceName: "vcenter-1.vrack.vsphere.local", ResourceType: "PSC"},
+	})
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, map[string]interface{}{
+		"name": "sfo-w01",
+		"vcenter_configuration": []interface{}{map[string]interface{}{
+			"fqdn": "vcenter-1.vrack.vsphere.local",
+		}},
+		"sso": []interface{}{map[string]interface{}{
+			"domain_name":                "vsphere.local",
+			"domain_password_wo":         "An0therL0ngP@ss123!",
+			"domain_password_wo_version": 2,
+		}},
+	})
+	data.SetId("domain-1")
+
+	// Only the SSO domain password has changed
+	err := updateAccountPasswords(context.Background(), data, client, domainPasswords(data)...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.RequestCount(http.MethodPatch, "/v1/credentials"))
+	credential, ok := server.Credential("vcenter-1.vrack.vsphere.local", "administrator@vsphere.local")
+	if assert.True(t, ok) {
+		assert.Equal(t, "An0therL0ngP@ss123!", *credential.Password)
+	}
+}
+
+func TestUpdateAccountPasswords_missingPassword(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":                "esxi-1.vrack.vsphere.local",
+		"username":            "root",
+		"password_wo_version": 2,
+	})
+
+	err := updateAccountPasswords(context.Background(), data, client, accountPassword{
+		path: "password", resourceType: "ESXI", resourceName: "esxi-1.vrack.vsphere.local", credentialType: "SSH", username: "root",
+	})
+
+	assert.ErrorContains(t, err, "password or password_wo is required")
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/credentials"))
+}
+
+func TestUpdateAccountPasswords_passwordWithoutVersion(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":     "esxi-1.vrack.vsphere.local",
+		"username": "root",
+		"password": "An0therL0ngP@ss123!",
+	})
+
+	// Only a new version of password_wo rotates the password of the account
+	err := updateAccountPasswords(context.Background(), data, client, accountPassword{
+		path: "password", resourceType: "ESXI", resourceName: "esxi-1.vrack.vsphere.local", credentialType: "SSH", username: "root",
+	})
+
+	assert.NoError(t, err)
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/credentials"))
+}
@@ -0,0 +1,176 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+const (
+	// taskStatusInProgress is the status of the SDDC Manager tasks which are still running.
+	taskStatusInProgress = "IN_PROGRESS"
+	// taskStatusSuccessful is the status of the SDDC Manager tasks which have completed successfully.
+	taskStatusSuccessful = "Successful"
+)
+
+// taskResource describes the resource created by a task, so that its ID can be found among the
+// resources of the task.
+type taskResource struct {
+	// Type is the type of the resource in the task, e.g. "Esxi".
+	Type string
+	// Name identifies the resource among the resources of the tasks, so that a creation whose
+	// task is still running or has completed is re-attached to rather than started again.
+	Name string
+	// Fqdn tells the resource apart from the other resources of the same type if the task
+	// creates several of them. Used instead of Name if set.
+	Fqdn string
+	// exists reports whether the resource with the given ID exists, so that the resource
+	// created by a task which has completed since an earlier run is adopted. Optional, only
+	// running tasks are re-attached to without it.
+	exists func(ctx context.Context, vcfClient *api_client.SddcManagerClient, id string) bool
+	// adoptFailed adopts a resource which exists although its task has failed, as a task
+	// which creates several resources fails if any of them fails. Requires exists.
+	adoptFailed bool
+}
+
+// matches reports whether a resource of a task is the resource.
+func (r taskResource) matches(resource vcf.Resource) bool {
+	if resource.Type != r.Type {
+		return false
+	}
+	if r.Fqdn != "" {
+		return resource.Fqdn != nil && strings.EqualFold(*resource.Fqdn, r.Fqdn)
+	}
+	return r.Name != "" && resource.Name != nil && *resource.Name == r.Name
+}
+
+// findIn returns the resource among the resources of a task, or nil if the task does not have it.
+func (r taskResource) findIn(task vcf.Task) *vcf.Resource {
+	if task.Id == nil || task.Resources == nil {
+		return nil
+	}
+	for _, resource := range *task.Resources {
+		if r.matches(resource) {
+			return &resource
+		}
+	}
+	return nil
+}
+
+// getId returns the ID of the resource among the resources of the task.
+func (r taskResource) getId(ctx context.Context, vcfClient *api_client.SddcManagerClient, taskId string) (string, error) {
+	if r.Fqdn != "" {
+		return vcfClient.GetResourceIdAssociatedWithTaskByFqdn(ctx, taskId, r.Type, r.Fqdn)
+	}
+	return vcfClient.GetResourceIdAssociatedWithTask(ctx, taskId, r.Type)
+}
+
+// getCreatedId returns the ID of the resource if it has been created by a task which has failed.
+func (r taskResource) getCreatedId(ctx context.Context, vcfClient *api_client.SddcManagerClient, taskId string) (string, bool) {
+	if !r.adoptFailed || r.exists == nil {
+		return "", false
+	}
+	id, err := r.getId(ctx, vcfClient, taskId)
+	if err != nil || !r.exists(ctx, vcfClient, id) {
+		return "", false
+	}
+	return id, true
+}
+
+// findCreationTask returns the ID of a task which creates the resource and is still running, or
+// has completed and created the resource which still exists. It returns an empty string if there
+// is none.
+//
+// Terraform saves the state of a resource only once its creation has returned, so nothing is
+// saved if the process is killed while it waits for the creation task. SDDC Manager keeps the
+// task from the moment it has been accepted, so the next run finds it and re-attaches to it, or
+// adopts the resource if the task has completed in the meantime, rather than starting the
+// creation a second time.
+func findCreationTask(ctx context.Context, vcfClient *api_client.SddcManagerClient, resource taskResource) (string, error) {
+	if resource.Name == "" && resource.Fqdn == "" {
+		return "", nil
+	}
+	task, err := findTask(ctx, vcfClient, taskStatusInProgress, func(task vcf.Task) bool {
+		return resource.findIn(task) != nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if task != nil {
+		tflog.Info(ctx, fmt.Sprintf("Re-attaching to running task with ID = %s which creates the %s", *task.Id, resource.Type))
+		return *task.Id, nil
+	}
+	if resource.exists == nil {
+		return "", nil
+	}
+
+	task, err = findTask(ctx, vcfClient, taskStatusSuccessful, func(task vcf.Task) bool {
+		created := resource.findIn(task)
+		return created != nil && created.ResourceId != "" && resource.exists(ctx, vcfClient, created.ResourceId)
+	})
+	if err != nil || task == nil {
+		return "", err
+	}
+	tflog.Info(ctx, fmt.Sprintf("Adopting the %s created by completed task with ID = %s", resource.Type, *task.Id))
+	return *task.Id, nil
+}
+
+// findTask returns the first task with the given status which matches, or nil if there is none.
+func findTask(ctx context.Context, vcfClient *api_client.SddcManagerClient, status string,
+	match func(task vcf.Task) bool) (*vcf.Task, error) {
+	params := &vcf.GetTasksParams{TaskStatus: utils.ToStringPointer(status)}
+	return api_client.FindInPages(ctx, func(pageNumber int) (api_client.Response, error) {
+		params.PageNumber = utils.ToInt32Pointer(pageNumber)
+		return vcfClient.ApiClient.GetTasksWithResponse(ctx, params)
+	}, match)
+}
+
+// awaitResourceCreation waits for the task which creates a resource and sets the ID of the
+// resource once the task has completed.
+//
+// If waiting is interrupted while the task keeps running, nothing is saved in the state and an
+// error is returned. The next run re-attaches to the task with findCreationTask.
+func awaitResourceCreation(ctx context.Context, data *schema.ResourceData, tracker *api_client.TaskTracker,
+	vcfClient *api_client.SddcManagerClient, taskId string, resource taskResource) diag.Diagnostics {
+	err := tracker.WaitForTask()
+
+	var interrupted *api_client.TaskInterruptedError
+	if errors.As(err, &interrupted) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "The creation of the resource is still in progress",
+			Detail: fmt.Sprintf("%s.\n\nThe next Terraform run re-attaches to the task and adopts the resource "+
+				"once the task has completed, rather than creating it again.", err),
+		}}
+	}
+	if err != nil {
+		if resourceId, ok := resource.getCreatedId(ctx, vcfClient, taskId); ok {
+			tflog.Warn(ctx, fmt.Sprintf("Task with ID = %s has failed, but created %s with ID = %s: %s",
+				taskId, resource.Type, resourceId, err))
+			data.SetId(resourceId)
+			return nil
+		}
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+
+	resourceId, err := resource.getId(ctx, vcfClient, taskId)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	data.SetId(resourceId)
+	return nil
+}
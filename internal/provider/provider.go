@@ -6,24 +6,28 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/version"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credential_source"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 // Provider returns the resource configuration of the provider.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return traceProvider(dryRunProvider(&schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"sddc_manager_username": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Description:   "The username to authenticate to the SDDC Manager instance.",
-				ConflictsWith: []string{"installer_username", "installer_password", "installer_host"},
+				ConflictsWith: []string{"sddc_manager_api_key", "sddc_manager_access_token"},
 				RequiredWith:  []string{"sddc_manager_password", "sddc_manager_host"},
 				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfTestUsername, nil),
 			},
@@ -31,41 +35,70 @@ func Provider() *schema.Provider {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Description:   "The password to authenticate to the SDDC Manager instance.",
-				ConflictsWith: []string{"installer_username", "installer_password", "installer_host"},
+				ConflictsWith: []string{"sddc_manager_api_key", "sddc_manager_access_token"},
 				RequiredWith:  []string{"sddc_manager_username", "sddc_manager_host"},
 				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfTestPassword, nil),
 			},
-			"sddc_manager_host": {
+			"sddc_manager_api_key": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				Description:   "The fully qualified domain name or IP address of the SDDC Manager instance.",
-				ConflictsWith: []string{"installer_username", "installer_password", "installer_host"},
-				RequiredWith:  []string{"sddc_manager_username", "sddc_manager_password"},
-				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfTestUrl, nil),
+				Sensitive:     true,
+				Description:   "The API key of a service account to authenticate to the SDDC Manager instance, instead of a username and password. Can also be set with the VCF_API_KEY environment variable.",
+				ConflictsWith: []string{"sddc_manager_access_token"},
+				RequiredWith:  []string{"sddc_manager_host"},
+				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfApiKey, nil),
+			},
+			"sddc_manager_access_token": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "An access token issued by the SDDC Manager instance, used instead of logging in. The token cannot be renewed, it has to be valid for the whole run. Can also be set with the VCF_ACCESS_TOKEN environment variable.",
+				RequiredWith: []string{"sddc_manager_host"},
+				DefaultFunc:  schema.EnvDefaultFunc(constants.VcfAccessToken, nil),
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A command which prints the credentials of the provider and other secrets as a JSON object, e.g. to retrieve them from a vault. Credentials set in the provider configuration take precedence. Can also be set with the VCF_CREDENTIAL_PROCESS environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfCredentialProcess, nil),
+			},
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a credentials file with named profiles of credentials and other secrets. Default is ~/.vcf/credentials if a profile is set. Can also be set with the VCF_CREDENTIALS_FILE environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfCredentialsFile, nil),
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The profile of the credentials file to use. Default is \"default\" if a credentials file is set. Can also be set with the VCF_PROFILE environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfProfile, nil),
+			},
+			"sddc_manager_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The fully qualified domain name or IP address of the SDDC Manager instance.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfTestUrl, nil),
 			},
 			"installer_username": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Description:   "The username to authenticate to the installer.",
-				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_host"},
-				RequiredWith:  []string{"installer_password", "installer_host"},
-				DefaultFunc:   schema.EnvDefaultFunc(constants.InstallerTestUsername, nil),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The username to authenticate to the installer.",
+				RequiredWith: []string{"installer_password", "installer_host"},
+				DefaultFunc:  schema.EnvDefaultFunc(constants.InstallerTestUsername, nil),
 			},
 			"installer_password": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Description:   "The password to authenticate to the installer.",
-				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_host"},
-				RequiredWith:  []string{"installer_username", "installer_host"},
-				DefaultFunc:   schema.EnvDefaultFunc(constants.InstallerTestPassword, nil),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The password to authenticate to the installer.",
+				RequiredWith: []string{"installer_username", "installer_host"},
+				DefaultFunc:  schema.EnvDefaultFunc(constants.InstallerTestPassword, nil),
 			},
 			"installer_host": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Description:   "The fully qualified domain name or IP address of the installer.",
-				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_host"},
-				RequiredWith:  []string{"installer_username", "installer_password"},
-				DefaultFunc:   schema.EnvDefaultFunc(constants.InstallerTestUrl, nil),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The fully qualified domain name or IP address of the installer.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.InstallerTestUrl, nil),
 			},
 			"allow_unverified_tls": {
 				Type:        schema.TypeBool,
@@ -73,81 +106,258 @@ func Provider() *schema.Provider {
 				Description: "Allow unverified TLS certificates.",
 				DefaultFunc: schema.EnvDefaultFunc(constants.VcfTestAllowUnverifiedTls, false),
 			},
+			"sddc_manager_ca_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The PEM encoded certificate, or the path of a PEM file, of the CA which signed the certificate of the SDDC Manager. It is trusted in addition to the system CAs.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfCaCertificate, nil),
+			},
+			"sddc_manager_server_thumbprint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The SHA-256 thumbprint of the certificate of the SDDC Manager. If set, the SDDC Manager is trusted if and only if its certificate matches the thumbprint, which also works for self-signed certificates.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfServerThumbprint, nil),
+			},
+			"installer_ca_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The PEM encoded certificate, or the path of a PEM file, of the CA which signed the certificate of the installer. It is trusted in addition to the system CAs.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.InstallerCaCertificate, nil),
+			},
+			"installer_server_thumbprint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The SHA-256 thumbprint of the certificate of the installer. If set, the installer is trusted if and only if its certificate matches the thumbprint, which also works for self-signed certificates.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.InstallerServerThumbprint, nil),
+			},
+			"cancel_tasks_on_interrupt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Cancel a running SDDC Manager task when waiting for it is interrupted, e.g. because the resource timeout has expired. By default the task is left running and can be tracked in the SDDC Manager UI.",
+				Default:     false,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The number of times a read or login request that failed with a transient error, such as a connection reset or a 429, 502 or 503 response, is retried. Set to 0 to disable retries. Default is 4.",
+				Default:      api_client.DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum delay in seconds between two retries of a failed request. Default is 30.",
+				Default:      int(api_client.DefaultRetryMaxBackoff.Seconds()),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the HTTP proxy to reach the SDDC Manager or installer through, e.g. http://proxy.example.com:3128. Hosts in the NO_PROXY environment variable are reached directly. By default the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables.",
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of requests per second sent to the SDDC Manager or installer. Requests wait until they are allowed. Set to 0 to disable the limit. Default is 10.",
+				Default:      api_client.DefaultMaxRequestsPerSecond,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_request_burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The number of requests which may be sent to the SDDC Manager or installer at once before max_requests_per_second applies. Default is 1, which spreads the requests evenly.",
+				Default:      api_client.DefaultMaxRequestBurst,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of requests in flight to the SDDC Manager or installer at the same time. Set to 0 to disable the limit. Default is 6.",
+				Default:      api_client.DefaultMaxConcurrentRequests,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"inventory_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Cache the domains, clusters, hosts, network pools and edge clusters read from the SDDC Manager for the run, so that a refresh retrieves every list and every element once. Each response is cached by its own URL. The cache is cleared whenever the provider changes the inventory or a task finishes. Set to false if the inventory is changed outside of Terraform during a run. Default is true.",
+				Default:     true,
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If true, the create, update and delete operations of the resources build their spec and only run the validation of SDDC Manager or the VCF Installer for it, reporting the results as diagnostics, and never start a workflow. Operations which cannot be validated fail without any change. Default is false.",
+				Default:     false,
+			},
+			"audit_log_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a file to which every request sent to the SDDC Manager or installer and its response are appended as JSON lines, with passwords, secrets, tokens and API keys redacted. Can also be set with the VCF_AUDIT_LOG_PATH environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfAuditLogPath, nil),
+			},
+			"connect_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum time in seconds to establish a connection, including the TLS handshake. Set to 0 to disable the limit. Default is 30.",
+				Default:      int(api_client.DefaultConnectTimeout.Seconds()),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum time in seconds a request takes, from sending it until its response has been read. Set to 0 to disable the limit. Default is 300.",
+				Default:      int(api_client.DefaultRequestTimeout.Seconds()),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"keep_alive": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The interval in seconds between TCP keep-alive probes of a connection. Set to 0 to disable keep-alive and use a new connection for every request. Default is 30.",
+				Default:      int(api_client.DefaultKeepAlive.Seconds()),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"idle_connection_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The time in seconds an idle connection is kept open for reuse. Default is 90.",
+				Default:      int(api_client.DefaultIdleConnectionTimeout.Seconds()),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"vcf_cluster":      DataSourceCluster(),
-			"vcf_credentials":  DataSourceCredentials(),
-			"vcf_domain":       DataSourceDomain(),
-			"vcf_host":         DataSourceHost(),
-			"vcf_network_pool": DataSourceNetworkPool(),
-			"vcf_certificate":  DataSourceCertificate(),
+			"vcf_cluster":      sddcManagerResource(DataSourceCluster()),
+			"vcf_credentials":  sddcManagerResource(DataSourceCredentials()),
+			"vcf_domain":       sddcManagerResource(DataSourceDomain()),
+			"vcf_host":         sddcManagerResource(DataSourceHost()),
+			"vcf_network_pool": sddcManagerResource(DataSourceNetworkPool()),
+			"vcf_certificate":  sddcManagerResource(DataSourceCertificate()),
+			"vcf_secret":       DataSourceSecret(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"vcf_certificate":                    ResourceCertificate(),
-			"vcf_certificate_authority":          ResourceCertificateAuthority(),
-			"vcf_ceip":                           ResourceCeip(),
-			"vcf_cluster":                        ResourceCluster(),
-			"vcf_cluster_personality":            ResourceClusterPersonality(),
-			"vcf_credentials_auto_rotate_policy": ResourceCredentialsAutoRotatePolicy(),
-			"vcf_credentials_rotate":             ResourceCredentialsRotate(),
-			"vcf_credentials_update":             ResourceCredentialsUpdate(),
-			"vcf_csr":                            ResourceCsr(),
-			"vcf_domain":                         ResourceDomain(),
-			"vcf_edge_cluster":                   ResourceEdgeCluster(),
-			"vcf_external_certificate":           ResourceExternalCertificate(),
-			"vcf_host":                           ResourceHost(),
-			"vcf_instance":                       ResourceVcfInstance(),
-			"vcf_user":                           ResourceUser(),
+			"vcf_certificate":                    sddcManagerResource(ResourceCertificate()),
+			"vcf_certificate_authority":          sddcManagerResource(ResourceCertificateAuthority()),
+			"vcf_ceip":                           sddcManagerResource(ResourceCeip()),
+			"vcf_cluster":                        sddcManagerResource(ResourceCluster()),
+			"vcf_cluster_personality":            sddcManagerResource(ResourceClusterPersonality()),
+			"vcf_credentials_auto_rotate_policy": sddcManagerResource(ResourceCredentialsAutoRotatePolicy()),
+			"vcf_credentials_rotate":             sddcManagerResource(ResourceCredentialsRotate()),
+			"vcf_credentials_update":             sddcManagerResource(ResourceCredentialsUpdate()),
+			"vcf_csr":                            sddcManagerResource(ResourceCsr()),
+			"vcf_domain":                         sddcManagerResource(ResourceDomain()),
+			"vcf_edge_cluster":                   sddcManagerResource(ResourceEdgeCluster()),
+			"vcf_external_certificate":           sddcManagerResource(ResourceExternalCertificate()),
+			"vcf_host":                           sddcManagerResource(ResourceHost()),
+			"vcf_instance":                       installerResource(ResourceVcfInstance()),
+			"vcf_user":                           sddcManagerResource(ResourceUser()),
 		},
 
 		ConfigureContextFunc: providerConfigure,
-	}
+	}))
 }
 
-func providerConfigure(_ context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	sddcManagerUsername, isVcfUsernameSet := data.GetOk("sddc_manager_username")
-	installerUsername, isInstallerUsernameSet := data.GetOk("installer_username")
+func providerConfigure(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	credentials, err := loadCredentials(ctx, credential_source.Source{
+		CredentialProcess: data.Get("credential_process").(string),
+		CredentialsFile:   data.Get("credentials_file").(string),
+		Profile:           data.Get("profile").(string),
+	})
+	if err != nil {
+		return nil, validationutils.ConvertVcfErrorToDiag(err)
+	}
+	getCredential := func(key, fallback string) string {
+		if value, ok := data.GetOk(key); ok {
+			return value.(string)
+		}
+		return fallback
+	}
+
+	sddcManagerUsername := getCredential("sddc_manager_username", credentials.SddcManagerUsername)
+	sddcManagerPassword := getCredential("sddc_manager_password", credentials.SddcManagerPassword)
+	sddcManagerApiKey := getCredential("sddc_manager_api_key", credentials.SddcManagerApiKey)
+	sddcManagerAccessToken := getCredential("sddc_manager_access_token", credentials.SddcManagerAccessToken)
+	installerUsername := getCredential("installer_username", credentials.InstallerUsername)
+	installerPassword := getCredential("installer_password", credentials.InstallerPassword)
 	allowUnverifiedTLS := data.Get("allow_unverified_tls")
+	clientOptions := []api_client.ClientOption{
+		api_client.WithRetry(
+			data.Get("max_retries").(int),
+			time.Duration(data.Get("retry_max_backoff").(int))*time.Second),
+		api_client.WithCancelTasksOnInterrupt(data.Get("cancel_tasks_on_interrupt").(bool)),
+		api_client.WithProxyUrl(data.Get("proxy_url").(string)),
+		api_client.WithRequestLimits(
+			data.Get("max_requests_per_second").(int),
+			data.Get("max_concurrent_requests").(int)),
+		api_client.WithRequestBurst(data.Get("max_request_burst").(int)),
+		api_client.WithInventoryCache(data.Get("inventory_cache").(bool)),
+		api_client.WithAuditLog(data.Get("audit_log_path").(string)),
+		api_client.WithTimeouts(
+			time.Duration(data.Get("connect_timeout").(int))*time.Second,
+			time.Duration(data.Get("request_timeout").(int))*time.Second),
+		api_client.WithKeepAlive(
+			time.Duration(data.Get("keep_alive").(int))*time.Second,
+			time.Duration(data.Get("idle_connection_timeout").(int))*time.Second),
+	}
 
-	if !isVcfUsernameSet && !isInstallerUsernameSet {
+	isSddcManagerSet := sddcManagerUsername != "" || sddcManagerApiKey != "" || sddcManagerAccessToken != ""
+	if !isSddcManagerSet && installerUsername == "" {
 		return nil, diag.Errorf("Either SDDC Manager or Installer configuration must be provided.")
 	}
 
-	if isVcfUsernameSet {
-		password, isSetPassword := data.GetOk("sddc_manager_password")
+	clients := &providerClients{credentials: credentials, dryRun: data.Get("dry_run").(bool)}
+
+	if isSddcManagerSet {
 		hostName, isSetHost := data.GetOk("sddc_manager_host")
-		if !isSetPassword || !isSetHost {
-			return nil, diag.Errorf("SDDC Manager username, password, and host must be provided.")
+		if !isSetHost || (sddcManagerUsername != "" && sddcManagerPassword == "") {
+			return nil, diag.Errorf("SDDC Manager host and either username and password, API key or access token must be provided.")
 		}
-		sddcManagerClient := api_client.NewSddcManagerClient(
-			sddcManagerUsername.(string),
-			password.(string),
+		sddcManagerOptions := append([]api_client.ClientOption{
+			api_client.WithApiKey(sddcManagerApiKey),
+			api_client.WithAccessToken(sddcManagerAccessToken),
+			api_client.WithCaCertificate(data.Get("sddc_manager_ca_certificate").(string)),
+			api_client.WithServerThumbprint(data.Get("sddc_manager_server_thumbprint").(string)),
+		}, clientOptions...)
+		sddcManagerClient, err := api_client.GetSddcManagerClient(
+			sddcManagerUsername,
+			sddcManagerPassword,
 			hostName.(string),
 			version.ProviderVersion,
-			allowUnverifiedTLS.(bool))
-		err := sddcManagerClient.Connect()
+			allowUnverifiedTLS.(bool),
+			sddcManagerOptions...)
 		if err != nil {
-			return nil, diag.FromErr(err)
+			return nil, validationutils.ConvertVcfErrorToDiag(err)
 		}
-		return sddcManagerClient, nil
+		clients.sddcManager = sddcManagerClient
 	}
 
-	if isInstallerUsernameSet {
-		password, isSetPassword := data.GetOk("installer_password")
+	if installerUsername != "" {
 		hostName, isSetHost := data.GetOk("installer_host")
-		if !isSetPassword || !isSetHost {
+		if installerPassword == "" || !isSetHost {
 			return nil, diag.Errorf("Installer username, password, and host must be provided.")
 		}
-		installerClient := api_client.NewInstallerClient(installerUsername.(string), password.(string),
-			hostName.(string), allowUnverifiedTLS.(bool))
-		err := installerClient.Connect()
+		installerOptions := append([]api_client.ClientOption{
+			api_client.WithCaCertificate(data.Get("installer_ca_certificate").(string)),
+			api_client.WithServerThumbprint(data.Get("installer_server_thumbprint").(string)),
+		}, clientOptions...)
+		installerClient, err := api_client.GetInstallerClient(installerUsername, installerPassword,
+			hostName.(string), allowUnverifiedTLS.(bool), installerOptions...)
 		if err != nil {
-			return nil, diag.FromErr(err)
+			return nil, validationutils.ConvertVcfErrorToDiag(err)
 		}
-		return installerClient, nil
+		clients.installer = installerClient
 	}
 
-	return nil, diag.Errorf("Failed to configure the provider. Please check the provider configuration settings.")
+	return clients, nil
+}
+
+// loadCredentials retrieves the credentials from the credential process or the profile set in
+// the provider configuration. Setting only a profile reads it from the default credentials file.
+func loadCredentials(ctx context.Context, source credential_source.Source) (*credential_source.Credentials, error) {
+	if source.Profile != "" && source.CredentialsFile == "" {
+		source.CredentialsFile = defaultCredentialsFile
+	}
+	if !source.IsSet() {
+		return &credential_source.Credentials{}, nil
+	}
+	return api_client.LoadCredentials(ctx, source)
 }
@@ -0,0 +1,133 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credential_source"
+)
+
+var (
+	errSddcManagerNotConfigured = errors.New("the provider is not configured for SDDC Manager, " +
+		"set sddc_manager_host, sddc_manager_username and sddc_manager_password in the provider configuration")
+	errInstallerNotConfigured = errors.New("the provider is not configured for the VCF Installer, " +
+		"set installer_host, installer_username and installer_password in the provider configuration")
+)
+
+// defaultCredentialsFile is read if only the profile is set in the provider configuration.
+const defaultCredentialsFile = "~/.vcf/credentials"
+
+// providerClients holds the clients of the endpoints configured in the provider. The provider
+// can be configured for the SDDC Manager, the VCF Installer or both, the client of an endpoint
+// which is not configured is nil.
+type providerClients struct {
+	sddcManager *api_client.SddcManagerClient
+	installer   *api_client.InstallerClient
+
+	// The credentials retrieved from the credential process or credentials file, if any
+	credentials *credential_source.Credentials
+
+	// Whether the resources only validate their changes instead of submitting them
+	dryRun bool
+}
+
+// getSddcManagerClient returns the SDDC Manager client from the data of the provider. The data
+// is either the clients of the provider or, in unit tests, a client.
+func getSddcManagerClient(meta interface{}) (*api_client.SddcManagerClient, error) {
+	switch clients := meta.(type) {
+	case *providerClients:
+		if clients.sddcManager != nil {
+			return clients.sddcManager, nil
+		}
+	case *api_client.SddcManagerClient:
+		if clients != nil {
+			return clients, nil
+		}
+	}
+	return nil, errSddcManagerNotConfigured
+}
+
+// getInstallerClient returns the VCF Installer client from the data of the provider. The data
+// is either the clients of the provider or, in unit tests, a client.
+func getInstallerClient(meta interface{}) (*api_client.InstallerClient, error) {
+	switch clients := meta.(type) {
+	case *providerClients:
+		if clients.installer != nil {
+			return clients.installer, nil
+		}
+	case *api_client.InstallerClient:
+		if clients != nil {
+			return clients, nil
+		}
+	}
+	return nil, errInstallerNotConfigured
+}
+
+// sddcManagerResource makes the functions of a resource or data source receive the SDDC Manager
+// client as meta. They return an error if the provider is not configured for SDDC Manager.
+func sddcManagerResource(resource *schema.Resource) *schema.Resource {
+	return withClient(resource, func(meta interface{}) (interface{}, error) {
+		return getSddcManagerClient(meta)
+	})
+}
+
+// installerResource makes the functions of a resource receive the VCF Installer client as meta.
+// They return an error if the provider is not configured for the VCF Installer.
+func installerResource(resource *schema.Resource) *schema.Resource {
+	return withClient(resource, func(meta interface{}) (interface{}, error) {
+		return getInstallerClient(meta)
+	})
+}
+
+func withClient(resource *schema.Resource, getClient func(meta interface{}) (interface{}, error)) *schema.Resource {
+	resource.CreateContext = withClientContextFunc(resource.CreateContext, getClient)
+	resource.ReadContext = withClientContextFunc(resource.ReadContext, getClient)
+	resource.UpdateContext = withClientContextFunc(resource.UpdateContext, getClient)
+	resource.DeleteContext = withClientContextFunc(resource.DeleteContext, getClient)
+
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		importState := resource.Importer.StateContext
+		resource.Importer.StateContext = func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			client, err := getClient(meta)
+			if err != nil {
+				return nil, err
+			}
+			return importState(ctx, data, client)
+		}
+	}
+
+	if resource.CustomizeDiff != nil {
+		customizeDiff := resource.CustomizeDiff
+		resource.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			client, err := getClient(meta)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, diff, client)
+		}
+	}
+
+	return resource
+}
+
+func withClientContextFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](
+	f F, getClient func(meta interface{}) (interface{}, error)) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, err := getClient(meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, data, client)
+	}
+}
@@ -6,18 +6,38 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -30,6 +50,26 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_writeOnlyVersions(t *testing.T) {
+	for resourceType, resource := range Provider().ResourcesMap {
+		assertWriteOnlyVersions(t, resourceType, resource.Schema)
+	}
+}
+
+// assertWriteOnlyVersions asserts that every write-only attribute of a schema and of its blocks has
+// a version, as Terraform cannot detect the changes of write-only values.
+func assertWriteOnlyVersions(t *testing.T, path string, attributes map[string]*schema.Schema) {
+	for name, attribute := range attributes {
+		if attribute.WriteOnly {
+			version := strings.TrimSuffix(name, utils.WriteOnlySuffix) + utils.WriteOnlyVersionSuffix
+			assert.Contains(t, attributes, version, "%s.%s has no version", path, name)
+		}
+		if block, ok := attribute.Elem.(*schema.Resource); ok {
+			assertWriteOnlyVersions(t, path+"."+name, block.Schema)
+		}
+	}
+}
+
 func muxedFactories() map[string]func() (tfprotov6.ProviderServer, error) {
 	testAccProvider = Provider()
 	testAccFrameworkProvider = New()
@@ -136,3 +176,264 @@ func testAccHostsPreCheck(t *testing.T, numberOfHosts int) {
 		}
 	}
 }
+
+// testTaskPollingInterval is how often the clients of the unit tests poll the tasks of the fake servers.
+const testTaskPollingInterval = 10 * time.Millisecond
+
+// testClientOptions are the options of the clients of the unit tests. The fake servers are not
+// overloaded by bursts of requests, so the rate of the requests is not limited.
+var testClientOptions = []api_client.ClientOption{
+	api_client.WithTaskPollingInterval(testTaskPollingInterval),
+	api_client.WithRequestLimits(0, api_client.DefaultMaxConcurrentRequests),
+}
+
+// testSddcManagerClient starts a fake SDDC Manager and returns a client connected to it.
+// Used by unit tests which call the CRUD functions of the resources directly.
+func testSddcManagerClient(t *testing.T, opts ...api_client.ClientOption) (*fake_server.Server, *api_client.SddcManagerClient) {
+	server := fake_server.NewServer(t)
+	opts = append(slices.Clone(testClientOptions), opts...)
+	client := api_client.NewSddcManagerClient(fake_server.Username, fake_server.Password, server.Host(), "test", true, opts...)
+	if err := client.Connect(); err != nil {
+		t.Fatal("failed to connect to the fake SDDC Manager", err)
+	}
+	return server, client
+}
+
+// testInstallerClient starts a fake VCF Installer and returns a client connected to it.
+func testInstallerClient(t *testing.T) (*fake_server.Server, *api_client.InstallerClient) {
+	server := fake_server.NewServer(t)
+	client := api_client.NewInstallerClient(fake_server.Username, fake_server.Password, server.Host(), true, testClientOptions...)
+	if err := client.Connect(); err != nil {
+		t.Fatal("failed to connect to the fake VCF Installer", err)
+	}
+	return server, client
+}
+
+func TestProviderConfigure_sddcManagerAndInstaller(t *testing.T) {
+	sddcManager := fake_server.NewServer(t)
+	installer := fake_server.NewServer(t)
+	data := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"sddc_manager_host":     sddcManager.Host(),
+		"sddc_manager_username": fake_server.Username,
+		"sddc_manager_password": fake_server.Password,
+		"installer_host":        installer.Host(),
+		"installer_username":    fake_server.Username,
+		"installer_password":    fake_server.Password,
+		"allow_unverified_tls":  true,
+	})
+
+	meta, diags := providerConfigure(context.Background(), data)
+
+	assert.False(t, diags.HasError(), diags)
+	sddcManagerClient, err := getSddcManagerClient(meta)
+	assert.NoError(t, err)
+	assert.NotNil(t, sddcManagerClient)
+	installerClient, err := getInstallerClient(meta)
+	assert.NoError(t, err)
+	assert.NotNil(t, installerClient)
+}
+
+func TestSddcManagerResource_notConfigured(t *testing.T) {
+	_, installerClient := testInstallerClient(t)
+	meta := &providerClients{installer: installerClient}
+	resource := sddcManagerResource(ResourceHost())
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	data.SetId("host-1")
+
+	diags := resource.ReadContext(context.Background(), data, meta)
+
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "sddc_manager_host")
+	}
+	_, err := resource.Importer.StateContext(context.Background(), data, meta)
+	assert.ErrorIs(t, err, errSddcManagerNotConfigured)
+}
+
+func TestInstallerResource_notConfigured(t *testing.T) {
+	_, sddcManagerClient := testSddcManagerClient(t)
+	meta := &providerClients{sddcManager: sddcManagerClient}
+	resource := installerResource(ResourceVcfInstance())
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+
+	diags := resource.CreateContext(context.Background(), data, meta)
+
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "installer_host")
+	}
+}
+
+func TestProviderConfigure_unreachable(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"sddc_manager_host":     "127.0.0.1:1",
+		"sddc_manager_username": fake_server.Username,
+		"sddc_manager_password": fake_server.Password,
+		"max_retries":           0,
+	})
+
+	// The provider is configured without connecting to SDDC Manager
+	meta, diags := providerConfigure(context.Background(), data)
+	assert.False(t, diags.HasError(), diags)
+
+	// The connection error is reported by the resource which sends the first request
+	resource := sddcManagerResource(ResourceHost())
+	resourceData := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	resourceData.SetId("host-1")
+	diags = resource.ReadContext(context.Background(), resourceData, meta)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "failed to authenticate")
+	}
+}
+
+func TestProviderConfigure_apiKey(t *testing.T) {
+	server := fake_server.NewServer(t)
+	data := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"sddc_manager_host":    server.Host(),
+		"sddc_manager_api_key": fake_server.ApiKey,
+		"allow_unverified_tls": true,
+	})
+
+	meta, diags := providerConfigure(context.Background(), data)
+
+	assert.False(t, diags.HasError(), diags)
+	client, err := getSddcManagerClient(meta)
+	if assert.NoError(t, err) {
+		assert.NoError(t, client.Connect())
+	}
+}
+
+func TestProviderSchemas(t *testing.T) {
+	// The SDK v2 and the framework provider are muxed, their schemas must be identical
+	server, err := muxedFactories()["vcf"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range res.Diagnostics {
+		t.Error(diagnostic.Summary, diagnostic.Detail)
+	}
+}
+
+func TestProviderConfigure_profile(t *testing.T) {
+	server := fake_server.NewServer(t)
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	content := fmt.Sprintf("[lab]\nsddc_manager_api_key = %s\nsecret.esxi-1 = r00t\n", fake_server.ApiKey)
+	if err := os.WriteFile(credentialsFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"sddc_manager_host":    server.Host(),
+		"credentials_file":     credentialsFile,
+		"profile":              "lab",
+		"allow_unverified_tls": true,
+	})
+
+	meta, diags := providerConfigure(context.Background(), data)
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+	client, err := getSddcManagerClient(meta)
+	if assert.NoError(t, err) {
+		assert.NoError(t, client.Connect())
+	}
+
+	secret := schema.TestResourceDataRaw(t, DataSourceSecret().Schema, map[string]interface{}{"name": "esxi-1"})
+	diags = dataSourceSecretRead(context.Background(), secret, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "r00t", secret.Get("value"))
+
+	missing := schema.TestResourceDataRaw(t, DataSourceSecret().Schema, map[string]interface{}{"name": "esxi-2"})
+	diags = dataSourceSecretRead(context.Background(), missing, meta)
+	assert.True(t, diags.HasError())
+}
+
+// testFrameworkProviderConfigure configures a framework provider with the given settings.
+func testFrameworkProviderConfigure(t *testing.T, data FrameworkProviderModel) (*FrameworkProvider, *provider.ConfigureResponse) {
+	ctx := context.Background()
+	p := &FrameworkProvider{}
+	schemaRes := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaRes)
+	config := tfsdk.State{Schema: schemaRes.Schema, Raw: tftypes.NewValue(schemaRes.Schema.Type().TerraformType(ctx), nil)}
+	if diags := config.Set(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	res := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaRes.Schema, Raw: config.Raw}}, res)
+	return p, res
+}
+
+func TestFrameworkProviderConfigure_configOverEnv(t *testing.T) {
+	server := fake_server.NewServer(t)
+	// The configuration takes precedence over the environment, like in the SDK v2 provider
+	t.Setenv(constants.VcfTestUrl, "127.0.0.1:1")
+
+	p, res := testFrameworkProviderConfigure(t, FrameworkProviderModel{
+		SddcManagerHost:    types.StringValue(server.Host()),
+		SddcManagerApiKey:  types.StringValue(fake_server.ApiKey),
+		AllowUnverifiedTls: types.BoolValue(true),
+	})
+
+	if assert.False(t, res.Diagnostics.HasError(), res.Diagnostics) {
+		assert.NoError(t, p.SddcManagerClient.Connect())
+	}
+}
+
+func TestFrameworkProviderConfigure_serverThumbprintPerEndpoint(t *testing.T) {
+	server := fake_server.NewServer(t)
+	thumbprint := sha256.Sum256(server.Certificate().Raw)
+
+	// The thumbprint of the installer must not be enforced on SDDC Manager
+	p, res := testFrameworkProviderConfigure(t, FrameworkProviderModel{
+		SddcManagerHost:             types.StringValue(server.Host()),
+		SddcManagerApiKey:           types.StringValue(fake_server.ApiKey),
+		SddcManagerServerThumbprint: types.StringValue(hex.EncodeToString(thumbprint[:])),
+		InstallerServerThumbprint:   types.StringValue(strings.Repeat("AB", sha256.Size)),
+	})
+
+	if assert.False(t, res.Diagnostics.HasError(), res.Diagnostics) {
+		assert.NoError(t, p.SddcManagerClient.Connect())
+	}
+}
+
+func TestFrameworkProviderConfigure_missingHost(t *testing.T) {
+	t.Setenv(constants.VcfTestUrl, "")
+
+	_, res := testFrameworkProviderConfigure(t, FrameworkProviderModel{
+		SddcManagerUsername: types.StringValue(fake_server.Username),
+		SddcManagerPassword: types.StringValue(fake_server.Password),
+	})
+
+	if assert.True(t, res.Diagnostics.HasError()) {
+		assert.Contains(t, res.Diagnostics[0].Summary(), "SDDC Manager host")
+	}
+}
+
+func TestTraceResource(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	resource := traceResource("vcf_network_pool", "vcf_network_pool", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+		},
+		CreateContext: func(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			data.SetId("pool-1")
+			return diag.Errorf("the network pool is in use")
+		},
+	})
+	data := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"name": "pool"})
+	diags := resource.CreateContext(context.Background(), data, nil)
+	assert.True(t, diags.HasError())
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "vcf_network_pool.Create", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), tracing.AttributeResourceId.String("pool-1"))
+	}
+}
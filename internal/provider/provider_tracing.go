@@ -0,0 +1,77 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	frameworkdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
+)
+
+// traceResource records a span for every operation of a resource or data source, with the ID
+// of the resource as attribute. The API requests and the tasks of an operation are its children.
+func traceResource(spanPrefix, resourceType string, resource *schema.Resource) *schema.Resource {
+	resource.CreateContext = traceContextFunc(spanPrefix+".Create", resourceType, resource.CreateContext)
+	resource.ReadContext = traceContextFunc(spanPrefix+".Read", resourceType, resource.ReadContext)
+	resource.UpdateContext = traceContextFunc(spanPrefix+".Update", resourceType, resource.UpdateContext)
+	resource.DeleteContext = traceContextFunc(spanPrefix+".Delete", resourceType, resource.DeleteContext)
+	return resource
+}
+
+// traceProvider records the spans of the operations of all resources and data sources of the provider.
+func traceProvider(provider *schema.Provider) *schema.Provider {
+	for resourceType, resource := range provider.ResourcesMap {
+		traceResource(resourceType, resourceType, resource)
+	}
+	for dataSourceType, dataSource := range provider.DataSourcesMap {
+		traceResource("data."+dataSourceType, dataSourceType, dataSource)
+	}
+	return provider
+}
+
+func traceContextFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](
+	spanName, resourceType string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, span := tracing.StartSpan(ctx, spanName, tracing.AttributeResourceType.String(resourceType))
+		diags := f(ctx, data, meta)
+
+		span.SetAttributes(tracing.AttributeResourceId.String(data.Id()))
+		var err error
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				err = errors.Join(err, diagError(d.Summary, d.Detail))
+			}
+		}
+		tracing.EndSpan(span, err)
+		return diags
+	}
+}
+
+// endFrameworkSpan ends the span of an operation of a framework resource or data source.
+func endFrameworkSpan(span trace.Span, diags frameworkdiag.Diagnostics, id string) {
+	span.SetAttributes(tracing.AttributeResourceId.String(id))
+	var err error
+	for _, d := range diags.Errors() {
+		err = errors.Join(err, diagError(d.Summary(), d.Detail()))
+	}
+	tracing.EndSpan(span, err)
+}
+
+func diagError(summary, detail string) error {
+	if detail == "" {
+		return errors.New(summary)
+	}
+	return fmt.Errorf("%s: %s", summary, detail)
+}
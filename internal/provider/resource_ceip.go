@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"strings"
 	"time"
 
@@ -17,6 +16,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 const (
@@ -64,14 +64,14 @@ func resourceCeipRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	ceipResult, err := apiClient.GetCeipStatusWithResponse(ctx)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	resp, vcfErr := api_client.GetResponseAs[vcf.Ceip](ceipResult)
 
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	d.SetId(*resp.InstanceId)
@@ -97,17 +97,17 @@ func resourceCeipUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	res, err := apiClient.SetCeipStatusWithResponse(ctx, vcf.SetCeipStatusJSONRequestBody(enableApiParam))
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return resourceCeipRead(ctx, d, meta)
@@ -123,17 +123,17 @@ func resourceCeipDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	ceipAccepted, err := apiClient.SetCeipStatusWithResponse(ctx, DisableApiParam)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](ceipAccepted)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	d.SetId("")
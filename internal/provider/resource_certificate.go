@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +17,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/certificates"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceCertificate() *schema.Resource {
@@ -62,7 +62,7 @@ func resourceResourceCertificateCreate(ctx context.Context, data *schema.Resourc
 	csrID := data.Get("csr_id").(string)
 	csrIdComponents := strings.Split(csrID, ":")
 	if len(csrIdComponents) != 5 {
-		return diag.FromErr(fmt.Errorf("CSR ID invalid"))
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("CSR ID invalid"))
 	}
 
 	domainID := csrIdComponents[1]
@@ -72,7 +72,7 @@ func resourceResourceCertificateCreate(ctx context.Context, data *schema.Resourc
 
 	err := certificates.GenerateCertificateForResource(ctx, vcfClient, &domainID, &resourceType, &resourceFqdn, &caType)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	certificateOperationSpec := vcf.CertificateOperationSpec{
@@ -85,17 +85,17 @@ func resourceResourceCertificateCreate(ctx context.Context, data *schema.Resourc
 
 	res, err := apiClient.ReplaceCertificatesWithResponse(ctx, domainID, certificateOperationSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	data.SetId("cert:" + domainID + ":" + resourceType + ":" + *task.Id)
 
@@ -108,7 +108,7 @@ func resourceResourceCertificateRead(ctx context.Context, data *schema.ResourceD
 	csrID := data.Get("csr_id").(string)
 	csrIdComponents := strings.Split(csrID, ":")
 	if len(csrIdComponents) != 5 {
-		return diag.FromErr(fmt.Errorf("CSR ID invalid"))
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("CSR ID invalid"))
 	}
 
 	domainID := csrIdComponents[1]
@@ -116,7 +116,7 @@ func resourceResourceCertificateRead(ctx context.Context, data *schema.ResourceD
 
 	cert, err := certificates.ReadCertificate(ctx, apiClient, domainID, resourceFqdn)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	flattenedCert := certificates.FlattenCertificate(*cert)
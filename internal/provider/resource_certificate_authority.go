@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -17,6 +16,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -35,6 +35,9 @@ func ResourceCertificateAuthority() *schema.Resource {
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		CustomizeDiff: validateRequiredAttributesForCertificateAuthority,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			utils.RequireSecrets("microsoft.secret"),
+		},
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Type:        schema.TypeString,
@@ -70,11 +73,13 @@ func ResourceCertificateAuthority() *schema.Resource {
 						},
 						"secret": {
 							Type:         schema.TypeString,
-							Required:     true,
+							Optional:     true,
 							Sensitive:    true,
-							Description:  "Microsoft CA server password",
+							Description:  "Microsoft CA server password. Either secret or secret_wo is required",
 							ValidateFunc: validation.StringIsNotEmpty,
 						},
+						"secret_wo":         utils.WriteOnlySchema("secret", "Microsoft CA server password", validation.StringIsNotEmpty),
+						"secret_wo_version": utils.WriteOnlyVersionSchema("secret", true),
 					},
 				},
 			},
@@ -145,12 +150,12 @@ func resourceCertificateAuthorityCreate(ctx context.Context, data *schema.Resour
 
 	certificateAuthorityCreationSpec := getCertificateAuthorityCreationSpec(data)
 	if certificateAuthorityCreationSpec == nil {
-		return diag.FromErr(fmt.Errorf("certificateAuthorityCreationSpec is empty, there was an error converting schema attributes to SDK spec"))
+		return validationUtils.ConvertVcfErrorToDiag(fmt.Errorf("certificateAuthorityCreationSpec is empty, there was an error converting schema attributes to SDK spec"))
 	}
 
 	_, err := apiClient.CreateCertificateAuthorityWithResponse(ctx, *certificateAuthorityCreationSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	data.SetId(*getCaType(data))
 
@@ -164,13 +169,13 @@ func resourceCertificateAuthorityRead(ctx context.Context, data *schema.Resource
 
 	authorityResponse, err := apiClient.GetCertificateAuthorityByIdWithResponse(ctx, authorityId)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	certificateAuthority, vcfErr := api_client.GetResponseAs[vcf.CertificateAuthority](authorityResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	// The ID doubles as type as per API
@@ -217,12 +222,12 @@ func resourceCertificateAuthorityDelete(ctx context.Context, data *schema.Resour
 
 	caType := getCaType(data)
 	if caType == nil {
-		return diag.FromErr(fmt.Errorf("error deleting Certificate Authority: could not determine CA type"))
+		return validationUtils.ConvertVcfErrorToDiag(fmt.Errorf("error deleting Certificate Authority: could not determine CA type"))
 	}
 
 	_, err := apiClient.RemoveCertificateAuthorityWithResponse(ctx, *caType)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	data.SetId("")
@@ -231,7 +236,7 @@ func resourceCertificateAuthorityDelete(ctx context.Context, data *schema.Resour
 
 func getCertificateAuthorityCreationSpec(data *schema.ResourceData) *vcf.CertificateAuthorityCreationSpec {
 	certificateAuthorityCreationSpec := &vcf.CertificateAuthorityCreationSpec{}
-	microsoftConfig := data.Get("microsoft").([]interface{})
+	microsoftConfig := utils.GetWithWriteOnly(data, "microsoft").([]interface{})
 	openSslConfig := data.Get("open_ssl").([]interface{})
 
 	caType := getCaType(data)
@@ -244,7 +249,7 @@ func getCertificateAuthorityCreationSpec(data *schema.ResourceData) *vcf.Certifi
 		serverUrl := microsoftConfigMap["server_url"].(string)
 		templateName := microsoftConfigMap["template_name"].(string)
 		username := microsoftConfigMap["username"].(string)
-		secret := microsoftConfigMap["secret"].(string)
+		secret := utils.GetBlockSecret(microsoftConfigMap, "secret")
 		certificateAuthorityCreationSpec.MicrosoftCertificateAuthoritySpec = &vcf.MicrosoftCertificateAuthoritySpec{
 			ServerUrl:    serverUrl,
 			TemplateName: templateName,
@@ -57,6 +57,9 @@ func ResourceCluster() *schema.Resource {
 			},
 		},
 		Schema: clusterResourceSchema,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			utils.ConflictingSecrets("host.password", "vsan_stretch_configuration.secondary_fd_host.password"),
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Hour),
 			Read:   schema.DefaultTimeout(10 * time.Minute),
@@ -238,35 +241,68 @@ func resourceClusterCreate(ctx context.Context, data *schema.ResourceData, meta
 
 	clusterSpec, err := cluster.TryConvertResourceDataToClusterSpec(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	domainId, err := getDomainId(data, vcfClient.ApiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
-	clusterId, diagnostics := createCluster(ctx, domainId, *clusterSpec, vcfClient)
-	if diagnostics != nil {
-		return diagnostics
+	// The running creation task is looked up before the domain is locked, re-attaching to it
+	// starts no workflow and must not wait for the other operations on the domain
+	clusterResource := taskResource{Type: "Cluster", Name: *clusterSpec.Name, exists: clusterExistsIn(domainId)}
+	var taskId string
+	if !isDryRun(ctx) {
+		if taskId, err = findCreationTask(ctx, vcfClient, clusterResource); err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
 	}
+	if taskId == "" {
+		ctx, unlock, err := lockDomain(ctx, vcfClient, domainId)
+		if err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
+		defer unlock()
 
-	data.SetId(clusterId)
+		var diagnostics diag.Diagnostics
+		if taskId, diagnostics = startClusterCreation(ctx, domainId, *clusterSpec, vcfClient); diagnostics != nil {
+			return diagnostics
+		}
+	}
+
+	tracker := vcfClient.NewTaskTracker(ctx, taskId)
+	if diagnostics := awaitResourceCreation(ctx, data, tracker, vcfClient, taskId, clusterResource); diagnostics != nil {
+		return diagnostics
+	}
 
 	return resourceClusterRead(ctx, data, meta)
 }
 
+// clusterExistsIn returns a function which reports whether a cluster exists in the domain, so
+// that a cluster of the same name in another domain is not adopted.
+func clusterExistsIn(domainId string) func(ctx context.Context, vcfClient *api_client.SddcManagerClient, id string) bool {
+	return func(ctx context.Context, vcfClient *api_client.SddcManagerClient, id string) bool {
+		clusterResponse, err := vcfClient.ApiClient.GetClusterWithResponse(ctx, id)
+		if err != nil {
+			return false
+		}
+		clusterObj, vcfErr := api_client.GetResponseAs[vcf.Cluster](clusterResponse)
+		return vcfErr == nil && clusterObj != nil && clusterObj.Domain != nil && clusterObj.Domain.Id == domainId
+	}
+}
+
 func resourceClusterRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 
 	clusterResult, err := apiClient.GetClusterWithResponse(ctx, data.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	clusterObj, vcfErr := api_client.GetResponseAs[vcf.Cluster](clusterResult)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	_ = data.Set("primary_datastore_name", clusterObj.PrimaryDatastoreName)
@@ -282,7 +318,7 @@ func resourceClusterUpdate(ctx context.Context, data *schema.ResourceData, meta
 
 	clusterUpdateSpec, err := cluster.CreateClusterUpdateSpec(data, false)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	diagnostics := updateCluster(ctx, data.Id(), *clusterUpdateSpec, vcfClient)
@@ -305,6 +341,30 @@ func resourceClusterDelete(ctx context.Context, data *schema.ResourceData, meta
 }
 
 func createCluster(ctx context.Context, domainId string, clusterSpec vcf.ClusterSpec,
+	vcfClient *api_client.SddcManagerClient) (string, diag.Diagnostics) {
+	ctx, unlock, err := lockDomain(ctx, vcfClient, domainId)
+	if err != nil {
+		return "", validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	defer unlock()
+
+	taskId, diags := startClusterCreation(ctx, domainId, clusterSpec, vcfClient)
+	if diags != nil {
+		return "", diags
+	}
+	if err := vcfClient.NewTaskTracker(ctx, taskId).WaitForTask(); err != nil {
+		return "", validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	clusterId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, taskId, "Cluster")
+	if err != nil {
+		return "", validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	return clusterId, nil
+}
+
+// startClusterCreation validates the cluster spec and starts the creation of the cluster,
+// it returns the ID of the creation task.
+func startClusterCreation(ctx context.Context, domainId string, clusterSpec vcf.ClusterSpec,
 	vcfClient *api_client.SddcManagerClient) (string, diag.Diagnostics) {
 	apiClient := vcfClient.ApiClient
 	clusterCreationSpec := vcf.ClusterCreationSpec{
@@ -322,11 +382,14 @@ func createCluster(ctx context.Context, domainId string, clusterSpec vcf.Cluster
 	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return "", diag.FromErr(errors.New(*vcfErr.Message))
+		return "", validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 	if validationUtils.HasValidationFailed(validationResult) {
 		return "", validationUtils.ConvertValidationResultToDiag(validationResult)
 	}
+	if isDryRun(ctx) {
+		return "", dryRunPassed(fmt.Sprintf("the creation of cluster %s", *clusterSpec.Name))
+	}
 
 	accepted, err := apiClient.CreateClusterWithResponse(ctx, clusterCreationSpec)
 	if err != nil {
@@ -335,72 +398,84 @@ func createCluster(ctx context.Context, domainId string, clusterSpec vcf.Cluster
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](accepted)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return "", diag.FromErr(errors.New(*vcfErr.Message))
-	}
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return "", diag.FromErr(err)
+		return "", validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
-	clusterId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, *task.Id, "Cluster")
-	if err != nil {
-		return "", diag.FromErr(err)
-	}
-	return clusterId, nil
+	return *task.Id, nil
 }
 
 func updateCluster(ctx context.Context, clusterId string, clusterUpdateSpec vcf.ClusterUpdateSpec,
 	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	ctx, unlock, err := lockCluster(ctx, vcfClient, clusterId)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	defer unlock()
+
 	apiClient := vcfClient.ApiClient
 	validationDiagnostics := cluster.ValidateClusterUpdateOperation(ctx, clusterId, clusterUpdateSpec, apiClient)
 	if validationDiagnostics != nil {
 		return validationDiagnostics
 	}
+	if isDryRun(ctx) {
+		return dryRunPassed(fmt.Sprintf("the update of cluster %s", clusterId))
+	}
 
 	acceptedUpdateTask, err := apiClient.UpdateClusterWithResponse(ctx, clusterId, clusterUpdateSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](acceptedUpdateTask)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	return nil
 }
 
 func deleteCluster(ctx context.Context, clusterId string, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	if isDryRun(ctx) {
+		return dryRunNotValidated(fmt.Sprintf("the deletion of cluster %s", clusterId))
+	}
+
+	ctx, unlock, err := lockCluster(ctx, vcfClient, clusterId)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	defer unlock()
+
 	clusterUpdateSpec, err := cluster.CreateClusterUpdateSpec(nil, true)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	apiClient := vcfClient.ApiClient
 	log.Printf("Marking Cluster %s for deletion", clusterId)
 	acceptedUpdateRes, err := apiClient.UpdateClusterWithResponse(ctx, clusterId, *clusterUpdateSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	if acceptedUpdateRes.StatusCode() != 200 {
-		return diag.FromErr(fmt.Errorf("failed to mark cluster for deletion"))
+		return validationUtils.ConvertVcfErrorToDiag(fmt.Errorf("failed to mark cluster for deletion"))
 	}
 
 	log.Printf("Deleting Cluster %s", clusterId)
 	acceptedDeleteTask, err := apiClient.DeleteClusterWithResponse(ctx, clusterId, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](acceptedDeleteTask)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	return nil
 }
@@ -427,23 +502,17 @@ func getDomainId(data *schema.ResourceData, client *vcf.ClientWithResponses) (st
 }
 
 func getDomain(name string, client *vcf.ClientWithResponses) (*vcf.Domain, error) {
-	ok, err := client.GetDomainsWithResponse(context.Background(), nil)
-
+	ctx := context.Background()
+	domain, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return client.GetDomainsWithResponse(ctx, nil)
+	}, func(domain vcf.Domain) bool {
+		return domain.Name != nil && *domain.Name == name
+	})
 	if err != nil {
 		return nil, err
 	}
-	domainsList, vcfErr := api_client.GetResponseAs[vcf.PageOfDomain](ok)
-	if vcfErr != nil {
-		return nil, errors.New(*vcfErr.Message)
-	}
-
-	if domainsList != nil && len(*domainsList.Elements) > 0 {
-		for _, domain := range *domainsList.Elements {
-			if *domain.Name == name {
-				return &domain, nil
-			}
-		}
+	if domain == nil {
+		return nil, fmt.Errorf("domain %s not found", name)
 	}
-
-	return nil, fmt.Errorf("domain %s not found", name)
+	return domain, nil
 }
@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -16,6 +15,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 const (
@@ -62,14 +62,15 @@ func ResourceClusterPersonality() *schema.Resource {
 }
 
 func resourceClusterPersonalityCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api_client.SddcManagerClient).ApiClient
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	client := vcfClient.ApiClient
 
 	mode := uploadModeReferred
 	name := data.Get("name").(string)
 
 	vcenterId, err := getVcenterId(data, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	clusterId := data.Get("cluster_id").(string)
@@ -86,37 +87,32 @@ func resourceClusterPersonalityCreate(ctx context.Context, data *schema.Resource
 	uploadPersonalityTask, err := client.UploadPersonalityWithResponse(ctx, spec)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](uploadPersonalityTask)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
-	if err = api_client.NewTaskTracker(ctx, client, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
-	personalitiesResp, err := client.GetPersonalitiesWithResponse(ctx, &vcf.GetPersonalitiesParams{
-		PersonalityName: &name,
+	// The personalities are filtered by name, the first one is the one that has been uploaded
+	personality, err := api_client.FindInPages(ctx, func(pageNumber int) (api_client.Response, error) {
+		return client.GetPersonalitiesWithResponse(ctx, personalitiesPage(&name, pageNumber))
+	}, func(vcf.Personality) bool {
+		return true
 	})
 	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	personalities, vcfErr := api_client.GetResponseAs[vcf.PageOfPersonality](personalitiesResp)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
-	if personalities.Elements == nil || len(*personalities.Elements) == 0 {
+	if personality == nil {
 		return diag.Errorf("Personality %s not found", name)
-	} else {
-		elements := *personalities.Elements
-		data.SetId(*elements[0].PersonalityId)
 	}
+	data.SetId(*personality.PersonalityId)
 
 	return nil
 }
@@ -126,7 +122,7 @@ func resourceClusterPersonalityRead(ctx context.Context, data *schema.ResourceDa
 
 	// Just check if the personality exists. There are no computed attributes.
 	if _, err := client.GetPersonalityWithResponse(ctx, data.Id()); err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
@@ -139,7 +135,7 @@ func resourceClusterPersonalityDelete(ctx context.Context, data *schema.Resource
 	if _, err := client.DeletePersonalityWithResponse(ctx, &vcf.DeletePersonalityParams{
 		PersonalityId: &id,
 	}); err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
@@ -150,19 +146,28 @@ func getVcenterId(data *schema.ResourceData, meta interface{}) (*string, error)
 
 	domainId := data.Get("domain_id").(string)
 
-	if vcs, err := client.GetVcentersWithResponse(context.Background(), nil); err != nil {
+	ctx := context.Background()
+	vc, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return client.GetVcentersWithResponse(ctx, nil)
+	}, func(vc vcf.Vcenter) bool {
+		return vc.Domain.Id == domainId
+	})
+	if err != nil {
 		return nil, err
-	} else {
-		page, vcfErr := api_client.GetResponseAs[vcf.PageOfVcenter](vcs)
-		if vcfErr != nil {
-			return nil, errors.New(*vcfErr.Message)
-		}
-		for _, vc := range *page.Elements {
-			if vc.Domain.Id == domainId {
-				return vc.Id, nil
-			}
-		}
 	}
+	if vc == nil {
+		return nil, fmt.Errorf("vcenter for domain %s not found", domainId)
+	}
+
+	return vc.Id, nil
+}
+
+// personalitiesPageSize is the number of personalities retrieved per page.
+const personalitiesPageSize = 100
 
-	return nil, fmt.Errorf("vcenter for domain %s not found", domainId)
+// personalitiesPage returns the parameters which retrieve a page of the personalities with the
+// given name, the pages of personalities are numbered from 1.
+func personalitiesPage(name *string, pageNumber int) *vcf.GetPersonalitiesParams {
+	page, size := pageNumber+1, personalitiesPageSize
+	return &vcf.GetPersonalitiesParams{PersonalityName: name, Page: &page, Size: &size}
 }
@@ -8,15 +8,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -583,3 +590,91 @@ func clusterImportStateCheck(states []*terraform.InstanceState) error {
 	}
 	return fmt.Errorf("cluster InstanceState not found! Import failed")
 }
+
+func TestResourceClusterUpdate(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+
+	data := schema.TestResourceDataRaw(t, ResourceCluster().Schema, map[string]interface{}{
+		"name":      "sfo-w01-cl02",
+		"domain_id": domainId,
+	})
+	data.SetId(clusterId)
+
+	diags := resourceClusterUpdate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	cluster, _ := server.Cluster(clusterId)
+	assert.Equal(t, "sfo-w01-cl02", *cluster.Name)
+	assert.Equal(t, *cluster.PrimaryDatastoreName, data.Get("primary_datastore_name"))
+}
+
+func TestResourceClusterUpdate_failedValidation(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+	server.SetValidationChecks(vcf.ValidationCheck{
+		Description:   utils.ToStringPointer("Validate cluster name"),
+		ResultStatus:  fake_server.ValidationFailed,
+		ErrorResponse: &vcf.Error{Message: utils.ToStringPointer("Cluster name is already in use")},
+	})
+
+	data := schema.TestResourceDataRaw(t, ResourceCluster().Schema, map[string]interface{}{
+		"name":      "sfo-w01-cl02",
+		"domain_id": domainId,
+	})
+	data.SetId(clusterId)
+
+	diags := resourceClusterUpdate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/clusters/"+clusterId))
+}
+
+func TestResourceClusterCreate_runningTaskWhileDomainLocked(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(domainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+	server.AddTask(vcf.Task{Resources: &[]vcf.Resource{{ResourceId: clusterId, Type: "Cluster",
+		Name: utils.ToStringPointer("sfo-w01-cl01")}}}, fake_server.TaskStatusSuccessful)
+	_, unlock, err := lockDomain(context.Background(), client, domainId)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer unlock()
+
+	data := schema.TestResourceDataRaw(t, ResourceCluster().Schema, testClusterInput(domainId, testAddUnassignedHosts(server, 3)))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	diags := resourceClusterCreate(ctx, data, client)
+
+	// Re-attaching to the running task does not wait for the other operations on the domain
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, clusterId, data.Id())
+	assert.Zero(t, server.RequestCount(http.MethodPost, "/v1/clusters"))
+}
+
+func TestResourceClusterCreate_completedTaskInOtherDomain(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	domainId := server.AddDomain(vcf.Domain{})
+	otherDomainId := server.AddDomain(vcf.Domain{})
+	clusterId := server.AddCluster(otherDomainId, vcf.Cluster{Name: utils.ToStringPointer("sfo-w01-cl01")})
+	server.AddTask(vcf.Task{Status: utils.ToStringPointer(fake_server.TaskStatusSuccessful), Resources: &[]vcf.Resource{{
+		ResourceId: clusterId, Type: "Cluster", Name: utils.ToStringPointer("sfo-w01-cl01")}}})
+
+	data := schema.TestResourceDataRaw(t, ResourceCluster().Schema, testClusterInput(domainId, testAddUnassignedHosts(server, 3)))
+
+	diags := resourceClusterCreate(context.Background(), data, client)
+
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/clusters"), diags)
+	assert.NotEqual(t, clusterId, data.Id())
+}
+
+// testClusterInput returns the configuration of a cluster named sfo-w01-cl01 in the domain.
+func testClusterInput(domainId string, hostIds []string) map[string]interface{} {
+	input := testDomainInput(hostIds)["cluster"].([]interface{})[0].(map[string]interface{})
+	input["domain_id"] = domainId
+	return input
+}
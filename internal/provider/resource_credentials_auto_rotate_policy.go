@@ -16,6 +16,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceCredentialsAutoRotatePolicy() *schema.Resource {
@@ -75,7 +76,7 @@ func ResourceCredentialsAutoRotatePolicy() *schema.Resource {
 func resourceCredentialsAutoRotatePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := credentials.CreateAutoRotatePolicy(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return resourceCredentialsAutoRotatePolicyRead(ctx, d, meta)
@@ -87,13 +88,13 @@ func resourceCredentialsAutoRotatePolicyRead(ctx context.Context, d *schema.Reso
 	matchedCredentials = filterCredentials(d.Get("user_name").(string), d.Get("resource_id").(string), matchedCredentials)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	lenCredentials := len(matchedCredentials)
 	if lenCredentials != 1 {
 		err := fmt.Errorf("only one credential expected, received %v", lenCredentials)
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	id, err := createAutorotateID(d)
@@ -118,7 +119,7 @@ func resourceCredentialsAutoRotatePolicyRead(ctx context.Context, d *schema.Reso
 
 func resourceCredentialsAutoRotatePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if err := credentials.RemoveAutoRotatePolicy(ctx, d, meta); err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
@@ -15,6 +15,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceCredentialsRotate() *schema.Resource {
@@ -78,7 +79,7 @@ func resourceCredentialsPasswordRotationRead(ctx context.Context, data *schema.R
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 	creds, err := credentials.ReadCredentials(ctx, data, apiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	dataCreds := data.Get("credentials").([]interface{})
@@ -111,7 +112,7 @@ func resourceCredentialsPasswordRotationCreate(ctx context.Context, d *schema.Re
 
 	err := credentials.RotatePasswords(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	_ = d.Set("last_rotate_time", time.Now().Format(time.RFC3339))
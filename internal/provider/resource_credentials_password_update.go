@@ -15,6 +15,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceCredentialsUpdate() *schema.Resource {
@@ -77,7 +78,7 @@ func resourceCredentialsPasswordUpdateRead(ctx context.Context, data *schema.Res
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 	creds, err := credentials.ReadCredentials(ctx, data, apiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	dataCreds := data.Get("credentials").([]interface{})
@@ -111,7 +112,7 @@ func resourceCredentialsPasswordUpdateCreate(ctx context.Context, d *schema.Reso
 
 	err := credentials.UpdatePasswords(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	_ = d.Set("last_update_time", time.Now().Format(time.RFC3339))
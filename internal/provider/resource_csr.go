@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -19,6 +18,7 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/certificates"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceCsr() *schema.Resource {
@@ -143,29 +143,26 @@ func resourceCsrCreate(ctx context.Context, data *schema.ResourceData, meta inte
 
 	res, err := apiClient.GeneratesCSRsWithResponse(ctx, domainId, csrsGenerationSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	data.SetId(fmt.Sprintf("csr:%s:%s:%s:%s", domainId, resourceType, resourceFqdn, *task.Id))
 
-	getCsrResponse, err := apiClient.GetCSRsWithResponse(ctx, domainId)
+	csr, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return apiClient.GetCSRsWithResponse(ctx, domainId)
+	}, func(csr vcf.Csr) bool {
+		return isCsrOfResource(csr, resourceFqdn)
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfCsr](getCsrResponse)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
-	}
-
-	csr := getCsrByResourceFqdn(resourceFqdn, page.Elements)
 	flattenedCsr := certificates.FlattenCsr(csr)
 	_ = data.Set("csr", []interface{}{flattenedCsr})
 
@@ -184,15 +181,7 @@ func resourceCsrDelete(_ context.Context, _ *schema.ResourceData, _ interface{})
 	return nil
 }
 
-// getCsrByResourceFqdn SDDC Manager API doesn't return CSR resource type, just FQDN.
-func getCsrByResourceFqdn(resourceFqdn string, csrs *[]vcf.Csr) *vcf.Csr {
-	if len(resourceFqdn) < 1 || csrs != nil && len(*csrs) < 1 {
-		return nil
-	}
-	for _, csr := range *csrs {
-		if csr.Resource != nil && csr.Resource.Fqdn != nil && resourceFqdn == *csr.Resource.Fqdn {
-			return &csr
-		}
-	}
-	return nil
+// isCsrOfResource SDDC Manager API doesn't return CSR resource type, just FQDN.
+func isCsrOfResource(csr vcf.Csr, resourceFqdn string) bool {
+	return len(resourceFqdn) > 0 && csr.Resource != nil && csr.Resource.Fqdn != nil && resourceFqdn == *csr.Resource.Fqdn
 }
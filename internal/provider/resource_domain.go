@@ -6,8 +6,8 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"time"
 
@@ -20,6 +20,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/cluster"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
 	"github.com/vmware/terraform-provider-vcf/internal/domain"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
 	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
@@ -42,6 +43,11 @@ func ResourceDomain() *schema.Resource {
 				return domain.ImportDomain(ctx, data, apiClient, domainId, false)
 			},
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			resource_utils.RequireSecrets("sso.domain_password", "vcenter_configuration.root_password",
+				"nsx_configuration.nsx_manager_admin_password"),
+			resource_utils.ConflictingSecrets("nsx_configuration.nsx_manager_audit_password", "cluster.host.password"),
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(4 * time.Hour),
 			Read:   schema.DefaultTimeout(20 * time.Minute),
@@ -97,10 +103,12 @@ func ResourceDomain() *schema.Resource {
 						},
 						"domain_password": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							Sensitive:   true,
-							Description: "Password of the SSO domain",
+							Description: "Password of the SSO domain. Either domain_password or domain_password_wo is required",
 						},
+						"domain_password_wo":         resource_utils.WriteOnlySchema("domain_password", "Password of the SSO domain", nil),
+						"domain_password_wo_version": resource_utils.WriteOnlyVersionSchema("domain_password", false),
 					},
 				},
 			},
@@ -139,7 +147,18 @@ func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta i
 
 	domainCreationSpec, err := domain.CreateDomainCreationSpec(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+
+	domainResource := taskResource{Type: "Domain", Name: *domainCreationSpec.DomainName, exists: domainExists}
+	if !isDryRun(ctx) {
+		taskId, err := findCreationTask(ctx, vcfClient, domainResource)
+		if err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
+		if taskId != "" {
+			return awaitDomainCreation(ctx, data, meta, taskId, domainResource)
+		}
 	}
 
 	validateResponse, err := apiClient.ValidateDomainCreationSpecWithResponse(ctx, nil, *domainCreationSpec)
@@ -149,11 +168,14 @@ func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta i
 	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 	if validationUtils.HasValidationFailed(validationResult) {
 		return validationUtils.ConvertValidationResultToDiag(validationResult)
 	}
+	if isDryRun(ctx) {
+		return dryRunPassed(fmt.Sprintf("the creation of domain %s", *domainCreationSpec.DomainName))
+	}
 
 	accepted, err := apiClient.CreateDomainWithResponse(ctx, *domainCreationSpec)
 	if err != nil {
@@ -162,33 +184,41 @@ func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta i
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](accepted)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
-	}
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
-	}
-	domainId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, *task.Id, "Domain")
-	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
+	return awaitDomainCreation(ctx, data, meta, *task.Id, domainResource)
+}
 
-	data.SetId(domainId)
+// awaitDomainCreation waits for the task which creates the domain and reads the domain.
+func awaitDomainCreation(ctx context.Context, data *schema.ResourceData, meta interface{}, taskId string,
+	domainResource taskResource) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	tracker := vcfClient.NewTaskTracker(ctx, taskId)
+	if diags := awaitResourceCreation(ctx, data, tracker, vcfClient, taskId, domainResource); diags != nil {
+		return diags
+	}
 
 	return resourceDomainRead(ctx, data, meta)
 }
 
+func domainExists(ctx context.Context, vcfClient *api_client.SddcManagerClient, id string) bool {
+	domainResponse, err := vcfClient.ApiClient.GetDomainWithResponse(ctx, id)
+	return err == nil && domainResponse.StatusCode() == http.StatusOK
+}
+
 func resourceDomainRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 
 	domainObj, err := domain.SetBasicDomainAttributes(ctx, data.Id(), data, apiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	err = domain.ReadAndSetClustersDataToDomainResource(*domainObj.Clusters, ctx, data, apiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	nsxtClusterConfigRaw := data.Get("nsx_configuration").([]interface{})
 	nsxtClusterConfig := nsxtClusterConfigRaw[0].(map[string]interface{})
@@ -202,22 +232,33 @@ func resourceDomainUpdate(ctx context.Context, data *schema.ResourceData, meta i
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 
+	ctx, unlock, err := lockDomain(ctx, vcfClient, data.Id())
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	defer unlock()
+
+	// In dry-run mode every change is validated, the results of all of them are reported
+	var diags diag.Diagnostics
+
 	// Domain Update API supports only changes to domain name and Cluster Import
-	if data.HasChange("name") {
+	if data.HasChange("name") && isDryRun(ctx) {
+		diags = append(diags, validateDomainUpdate(ctx, data.Id(), domain.CreateDomainUpdateSpec(data, false), apiClient)...)
+	} else if data.HasChange("name") {
 		domainUpdateSpec := domain.CreateDomainUpdateSpec(data, false)
 
 		accepted, err := apiClient.UpdateDomainWithResponse(ctx, data.Id(), domainUpdateSpec)
 		if err != nil {
-			return diag.FromErr(err)
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
 		task, vcfErr := api_client.GetResponseAs[vcf.Task](accepted)
 		if vcfErr != nil {
 			api_client.LogError(vcfErr, ctx)
-			return diag.FromErr(errors.New(*vcfErr.Message))
+			return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 		}
 
-		if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-			return diag.FromErr(err)
+		if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
 	}
 
@@ -225,53 +266,98 @@ func resourceDomainUpdate(ctx context.Context, data *schema.ResourceData, meta i
 		oldClustersValue, newClustersValue := data.GetChange("cluster")
 		newClustersList := newClustersValue.([]interface{})
 		oldClustersList := oldClustersValue.([]interface{})
+		// The specs of the added hosts need the passwords, which are not part of the data if they are write-only
+		configuredClustersList := resource_utils.GetWithWriteOnly(data, "cluster").([]interface{})
 		if len(oldClustersList) == len(newClustersList) {
-			diags := handleClusterUpdateInDomain(ctx, newClustersList, oldClustersList, vcfClient)
-			if diags != nil {
-				return diags
-			}
+			diags = append(diags, handleClusterUpdateInDomain(ctx, newClustersList, configuredClustersList, oldClustersList, vcfClient)...)
 		} else {
-			diags := handleClusterAddRemoveToDomain(ctx, data.Id(), newClustersList, oldClustersList, vcfClient)
-			if diags != nil {
-				return diags
-			}
+			diags = append(diags, handleClusterAddRemoveToDomain(ctx, data.Id(), configuredClustersList, oldClustersList, vcfClient)...)
 		}
 	}
 
+	passwords := domainPasswords(data)
+	if hasPasswordChanges(data, passwords...) && isDryRun(ctx) {
+		diags = append(diags, dryRunNotValidated(fmt.Sprintf("the password update of domain %s", data.Id()))...)
+	} else if err = updateAccountPasswords(ctx, data, vcfClient, passwords...); err != nil {
+		diags = append(diags, validationUtils.ConvertVcfErrorToDiag(err)...)
+	}
+	if diags != nil {
+		return diags
+	}
+
 	return resourceDomainRead(ctx, data, meta)
 }
 
+// domainPasswords returns the passwords of the accounts of the vCenter Server, the NSX Manager
+// cluster and the SSO domain of a domain.
+func domainPasswords(data *schema.ResourceData) []accountPassword {
+	vcenterFqdn := data.Get("vcenter_configuration.0.fqdn").(string)
+	nsxFqdn := data.Get("nsx_configuration.0.vip_fqdn").(string)
+	return []accountPassword{
+		{path: "vcenter_configuration.0.root_password", resourceType: credentials.ResourceTypeVcenter, resourceName: vcenterFqdn,
+			credentialType: "SSH", username: "root"},
+		{path: "nsx_configuration.0.nsx_manager_admin_password", resourceType: credentials.ResourceTypeNsxtManager, resourceName: nsxFqdn,
+			credentialType: "API", username: "admin"},
+		{path: "nsx_configuration.0.nsx_manager_audit_password", resourceType: credentials.ResourceTypeNsxtManager, resourceName: nsxFqdn,
+			credentialType: "AUDIT", username: "audit"},
+		{path: "sso.0.domain_password", resourceType: credentials.ResourceTypePsc, resourceName: vcenterFqdn,
+			credentialType: "SSO", username: "administrator@" + data.Get("sso.0.domain_name").(string)},
+	}
+}
+
+// validateDomainUpdate validates the update of a domain in dry-run mode.
+func validateDomainUpdate(ctx context.Context, domainId string, domainUpdateSpec vcf.DomainUpdateSpec,
+	apiClient *vcf.ClientWithResponses) diag.Diagnostics {
+	validateResponse, err := apiClient.ValidateDomainUpdateSpecWithResponse(ctx, domainId, domainUpdateSpec)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
+	if vcfErr != nil {
+		api_client.LogError(vcfErr, ctx)
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
+	}
+	if validationUtils.HasValidationFailed(validationResult) {
+		return validationUtils.ConvertValidationResultToDiag(validationResult)
+	}
+	return dryRunPassed(fmt.Sprintf("the update of domain %s", domainId))
+}
+
 func handleClusterAddRemoveToDomain(ctx context.Context, domainId string, newClustersList, oldClustersList []interface{},
 	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
 	addedClustersList, removedClustersList := resource_utils.CalculateAddedRemovedResources(newClustersList, oldClustersList)
+	var diags diag.Diagnostics
 	for _, addedCluster := range addedClustersList {
 		clusterSpec, err := cluster.TryConvertToClusterSpec(addedCluster)
 		if err != nil {
-			return diag.FromErr(err)
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
 		// subsequent domain read will set the cluster ID, so we can discard it here
-		_, diags := createCluster(ctx, domainId, *clusterSpec, vcfClient)
-		if diags != nil {
-			return diags
+		_, clusterDiags := createCluster(ctx, domainId, *clusterSpec, vcfClient)
+		if clusterDiags != nil && !isDryRun(ctx) {
+			return clusterDiags
 		}
+		diags = append(diags, clusterDiags...)
 	}
 
 	for _, removedCluster := range removedClustersList {
 		clusterId := removedCluster["id"].(string)
-		diags := deleteCluster(ctx, clusterId, vcfClient)
-		if diags != nil {
-			return diags
+		clusterDiags := deleteCluster(ctx, clusterId, vcfClient)
+		if clusterDiags != nil && !isDryRun(ctx) {
+			return clusterDiags
 		}
+		diags = append(diags, clusterDiags...)
 	}
 
-	return nil
+	return diags
 }
 
-func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, oldClustersStateList []interface{},
+func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, configuredClustersList, oldClustersStateList []interface{},
 	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
 	if len(oldClustersStateList) != len(newClustersStateList) {
-		return diag.FromErr(fmt.Errorf("expecting old and new cluster list to have the same length"))
+		return validationUtils.ConvertVcfErrorToDiag(fmt.Errorf("expecting old and new cluster list to have the same length"))
 	}
+	var diags diag.Diagnostics
 	for i, newClusterState := range newClustersStateList {
 		// skip the clusters that have no changes
 		if reflect.DeepEqual(newClusterState, oldClustersStateList[i]) {
@@ -283,7 +369,7 @@ func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, oldC
 		newClusterStateId := newClusterStateMap["id"].(string)
 		oldClusterStateId := oldClusterStateMap["id"].(string)
 		if newClusterStateId != oldClusterStateId {
-			return diag.FromErr(fmt.Errorf("cluster order has changed, updating hosts in cluster not supported"))
+			return validationUtils.ConvertVcfErrorToDiag(fmt.Errorf("cluster order has changed, updating hosts in cluster not supported"))
 		}
 		oldHostsList := oldClusterStateMap["host"].([]interface{})
 		newHostsList := newClusterStateMap["host"].([]interface{})
@@ -292,47 +378,55 @@ func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, oldC
 			continue
 		}
 
+		configuredHostsList := configuredClustersList[i].(map[string]interface{})["host"].([]interface{})
 		clusterUpdateSpec := &vcf.ClusterUpdateSpec{}
-		populatedClusterUpdateSpec, err := cluster.SetExpansionOrContractionSpec(clusterUpdateSpec, oldHostsList, newHostsList)
+		populatedClusterUpdateSpec, err := cluster.SetExpansionOrContractionSpec(clusterUpdateSpec, oldHostsList, configuredHostsList)
 		if err != nil {
-			return diag.FromErr(err)
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
 
-		diags := updateCluster(ctx, newClusterStateId, *populatedClusterUpdateSpec, vcfClient)
-		if diags != nil {
-			return diags
+		clusterDiags := updateCluster(ctx, newClusterStateId, *populatedClusterUpdateSpec, vcfClient)
+		if clusterDiags != nil && !isDryRun(ctx) {
+			return clusterDiags
 		}
+		diags = append(diags, clusterDiags...)
 	}
-	return nil
+	return diags
 }
 
 func resourceDomainDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 
+	ctx, unlock, err := lockDomain(ctx, vcfClient, data.Id())
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	defer unlock()
+
 	markForDeleteUpdateSpec := domain.CreateDomainUpdateSpec(data, true)
 
 	acceptedUpdateTask, err := apiClient.UpdateDomainWithResponse(ctx, data.Id(), markForDeleteUpdateSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	_, vcfErr := api_client.GetResponseAs[vcf.Task](acceptedUpdateTask)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	acceptedDeleteTask, err := apiClient.DeleteDomainWithResponse(ctx, data.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](acceptedDeleteTask)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
@@ -8,14 +8,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -568,3 +576,202 @@ func domainImportStateCheck(states []*terraform.InstanceState) error {
 	}
 	return fmt.Errorf("domain InstanceState not found! Import failed")
 }
+
+func TestResourceDomainCreate(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	hostIds := testAddUnassignedHosts(server, 3)
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+
+	diags := resourceDomainCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	domain, ok := server.Domain(data.Id())
+	assert.True(t, ok, "domain %q was not created", data.Id())
+	assert.Equal(t, "sfo-w01-vc01", *domain.Name)
+	assert.Equal(t, "sfo-w01-vc01.vrack.vsphere.local", data.Get("vcenter_configuration.0.fqdn"))
+	assert.Equal(t, (*domain.Clusters)[0].Id, data.Get("cluster.0.id"))
+	assert.Equal(t, *domain.NsxtCluster.Id, data.Get("nsx_configuration.0.id"))
+}
+
+func TestResourceDomainCreate_rejected(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	hostIds := testAddUnassignedHosts(server, 3)
+	server.FailRequests(http.MethodPost, "/v1/domains", 1, http.StatusBadRequest, vcf.Error{
+		Message:            utils.ToStringPointer("Domain sfo-w01-vc01 already exists"),
+		RemediationMessage: utils.ToStringPointer("Choose a different domain name"),
+		ReferenceToken:     utils.ToStringPointer("K1FMMB"),
+	})
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+
+	diags := resourceDomainCreate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Domain sfo-w01-vc01 already exists", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "Choose a different domain name")
+	assert.Contains(t, diags[0].Detail, "K1FMMB")
+	assert.Empty(t, data.Id())
+}
+
+func TestResourceDomainCreate_failedTask(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	hostIds := testAddUnassignedHosts(server, 3)
+	server.ScriptNextTask(fake_server.TaskStatusFailed)
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+
+	diags := resourceDomainCreate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Empty(t, data.Id())
+}
+
+func TestResourceDomainCreate_interrupted(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	hostIds := testAddUnassignedHosts(server, 3)
+	// The task runs longer than the first run waits for it
+	server.ScriptNextTask(append(slices.Repeat([]string{fake_server.TaskStatusInProgress}, 20), fake_server.TaskStatusSuccessful)...)
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	diags := resourceDomainCreate(ctx, data, client)
+
+	assert.True(t, diags.HasError(), diags)
+	assert.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Detail, "context deadline exceeded")
+	assert.Empty(t, data.Id(), "the domain must not be saved in the state")
+
+	// The next run re-attaches to the running task and adopts the domain
+	data = schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+	diags = resourceDomainCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	_, ok := server.Domain(data.Id())
+	assert.True(t, ok, "domain %q was not adopted", data.Id())
+	assert.Equal(t, "sfo-w01-vc01.vrack.vsphere.local", data.Get("vcenter_configuration.0.fqdn"))
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/domains"))
+}
+
+func TestResourceDomainCreate_completedBetweenRuns(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	hostIds := testAddUnassignedHosts(server, 3)
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+	if diags := resourceDomainCreate(context.Background(), data, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+	domainId := data.Id()
+
+	// The state of the first run has not been saved, the next run adopts the created domain
+	data = schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+	diags := resourceDomainCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, domainId, data.Id())
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/domains"))
+}
+
+func TestResourceDomainCreate_otherRunningTask(t *testing.T) {
+	server, client := testSddcManagerClient(t)
+	hostIds := testAddUnassignedHosts(server, 3)
+	server.AddTask(vcf.Task{Resources: &[]vcf.Resource{{ResourceId: "domain-other", Type: "Domain",
+		Name: utils.ToStringPointer("sfo-w02")}}}, fake_server.TaskStatusInProgress)
+
+	data := schema.TestResourceDataRaw(t, ResourceDomain().Schema, testDomainInput(hostIds))
+
+	diags := resourceDomainCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/domains"))
+	assert.NotEqual(t, "domain-other", data.Id())
+}
+
+func testAddUnassignedHosts(server *fake_server.Server, count int) []string {
+	hostIds := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		hostIds = append(hostIds, server.AddHost(vcf.Host{
+			Fqdn:   utils.ToStringPointer(fmt.Sprintf("esxi-%d.vrack.vsphere.local", i)),
+			Status: utils.ToStringPointer("UNASSIGNED_USEABLE"),
+		}))
+	}
+	return hostIds
+}
+
+func testDomainInput(hostIds []string) map[string]interface{} {
+	hosts := make([]interface{}, 0, len(hostIds))
+	for _, hostId := range hostIds {
+		hosts = append(hosts, map[string]interface{}{
+			"id": hostId,
+			"vmnic": []interface{}{
+				map[string]interface{}{"id": "vmnic0", "vds_name": "sfo-w01-cl01-vds01"},
+				map[string]interface{}{"id": "vmnic1", "vds_name": "sfo-w01-cl01-vds01"},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"name": "sfo-w01-vc01",
+		"sso": []interface{}{
+			map[string]interface{}{
+				"domain_name":     "acc-test.vrack.vsphere.local",
+				"domain_password": "S@mpleL0ngP@ss123!",
+			},
+		},
+		"vcenter_configuration": []interface{}{
+			map[string]interface{}{
+				"name":            "test-vcenter",
+				"datacenter_name": "test-datacenter",
+				"root_password":   "S@mpleL0ngP@ss123!",
+				"vm_size":         "small",
+				"storage_size":    "lstorage",
+				"ip_address":      "10.0.0.143",
+				"subnet_mask":     "255.255.255.0",
+				"gateway":         "10.0.0.250",
+				"fqdn":            "sfo-w01-vc01.vrack.vsphere.local",
+			},
+		},
+		"nsx_configuration": []interface{}{
+			map[string]interface{}{
+				"vip":                        "10.0.0.166",
+				"vip_fqdn":                   "sfo-w01-nsx01.vrack.vsphere.local",
+				"nsx_manager_admin_password": "S@mpleL0ngP@ss123!",
+				"form_factor":                "small",
+				"nsx_manager_node": []interface{}{
+					map[string]interface{}{
+						"name":        "sfo-w01-nsx01a",
+						"ip_address":  "10.0.0.162",
+						"fqdn":        "sfo-w01-nsx01a.vrack.vsphere.local",
+						"subnet_mask": "255.255.255.0",
+						"gateway":     "10.0.0.250",
+					},
+				},
+			},
+		},
+		"cluster": []interface{}{
+			map[string]interface{}{
+				"name":                      "sfo-w01-cl01",
+				"high_availability_enabled": true,
+				"host":                      hosts,
+				"vds": []interface{}{
+					map[string]interface{}{
+						"name": "sfo-w01-cl01-vds01",
+						"portgroup": []interface{}{
+							map[string]interface{}{"name": "sfo-w01-cl01-vds01-pg-mgmt", "transport_type": "MANAGEMENT"},
+							map[string]interface{}{"name": "sfo-w01-cl01-vds01-pg-vsan", "transport_type": "VSAN"},
+							map[string]interface{}{"name": "sfo-w01-cl01-vds01-pg-vmotion", "transport_type": "VMOTION"},
+						},
+					},
+				},
+				"vsan_datastore": []interface{}{
+					map[string]interface{}{
+						"datastore_name":       "sfo-w01-cl01-ds-vsan01",
+						"failures_to_tolerate": 1,
+					},
+				},
+				"geneve_vlan_id": 3,
+			},
+		},
+	}
+}
@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -17,7 +16,9 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
 	"github.com/vmware/terraform-provider-vcf/internal/nsx_edge_cluster"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -35,6 +36,11 @@ func ResourceEdgeCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			utils.RequireSecrets("root_password", "admin_password", "audit_password", "edge_node.admin_password",
+				"edge_node.audit_password", "edge_node.root_password"),
+			utils.ConflictingSecrets("edge_node.uplink.bgp_peer.password"),
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(180 * time.Minute),
 			Update: schema.DefaultTimeout(180 * time.Minute),
@@ -48,22 +54,28 @@ func ResourceEdgeCluster() *schema.Resource {
 			},
 			"root_password": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Root user password for the NSX manager",
+				Optional:     true,
+				Description:  "Root user password for the NSX manager. Either root_password or root_password_wo is required",
 				ValidateFunc: validationUtils.ValidateNsxEdgePassword,
 			},
+			"root_password_wo":         utils.WriteOnlySchema("root_password", "Root user password for the NSX manager", validationUtils.ValidateNsxEdgePassword),
+			"root_password_wo_version": utils.WriteOnlyVersionSchema("root_password", false),
 			"admin_password": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Administrator password for the NSX manager",
+				Optional:     true,
+				Description:  "Administrator password for the NSX manager. Either admin_password or admin_password_wo is required",
 				ValidateFunc: validationUtils.ValidateNsxEdgePassword,
 			},
+			"admin_password_wo":         utils.WriteOnlySchema("admin_password", "Administrator password for the NSX manager", validationUtils.ValidateNsxEdgePassword),
+			"admin_password_wo_version": utils.WriteOnlyVersionSchema("admin_password", false),
 			"audit_password": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Audit user password for the NSX manager",
+				Optional:     true,
+				Description:  "Audit user password for the NSX manager. Either audit_password or audit_password_wo is required",
 				ValidateFunc: validationUtils.ValidateNsxEdgePassword,
 			},
+			"audit_password_wo":         utils.WriteOnlySchema("audit_password", "Audit user password for the NSX manager", validationUtils.ValidateNsxEdgePassword),
+			"audit_password_wo_version": utils.WriteOnlyVersionSchema("audit_password", false),
 			"tier0_name": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -154,31 +166,41 @@ func ResourceEdgeCluster() *schema.Resource {
 }
 
 func resourceNsxEdgeClusterCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api_client.SddcManagerClient).ApiClient
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	client := vcfClient.ApiClient
 
 	spec, err := nsx_edge_cluster.GetNsxEdgeClusterCreationSpec(data, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	validationErr := validateClusterCreationSpec(client, ctx, *spec)
 	if validationErr != nil {
 		return validationErr
 	}
+	if isDryRun(ctx) {
+		return dryRunPassed(fmt.Sprintf("the creation of edge cluster %s", spec.EdgeClusterName))
+	}
+
+	ctx, unlock, err := lockEdgeCluster(ctx, vcfClient, "", edgeNodeClusterIds(spec.EdgeNodeSpecs))
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	defer unlock()
 
 	res, err := client.CreateEdgeClusterWithResponse(ctx, *spec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	tflog.Info(ctx, "Edge cluster creation has started.")
-	if err = api_client.NewTaskTracker(ctx, client, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	const maxRetries = 30
@@ -187,25 +209,19 @@ func resourceNsxEdgeClusterCreate(ctx context.Context, data *schema.ResourceData
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		tflog.Info(ctx, fmt.Sprintf("Attempt %d/%d to find edge cluster", attempt+1, maxRetries))
 
-		clusters, err := client.GetEdgeClustersWithResponse(ctx, nil)
+		tflog.Info(ctx, fmt.Sprintf("Looking for cluster name: '%s'", data.Get("name").(string)))
+		cluster, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+			return client.GetEdgeClustersWithResponse(ctx, nil)
+		}, func(cluster vcf.EdgeCluster) bool {
+			return cluster.Name != nil && *cluster.Name == data.Get("name")
+		})
 		if err != nil {
-			return diag.FromErr(err)
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
-		page, vcfErr := api_client.GetResponseAs[vcf.PageOfEdgeCluster](clusters)
-		if vcfErr != nil {
-			api_client.LogError(vcfErr, ctx)
-			return diag.FromErr(errors.New(*vcfErr.Message))
-		}
-
-		tflog.Info(ctx, fmt.Sprintf("Looking for cluster name: '%s'", data.Get("name").(string)))
-		tflog.Info(ctx, fmt.Sprintf("Total clusters found in API response: %d", len(*page.Elements)))
-
-		for _, cluster := range *page.Elements {
-			if cluster.Name != nil && *cluster.Name == data.Get("name") {
-				data.SetId(*cluster.Id)
-				tflog.Info(ctx, "Edge cluster created successfully.")
-				return nil
-			}
+		if cluster != nil {
+			data.SetId(*cluster.Id)
+			tflog.Info(ctx, "Edge cluster created successfully.")
+			return nil
 		}
 
 		// Sleep after all attempts other than the last one.
@@ -223,7 +239,7 @@ func resourceNsxEdgeClusterRead(ctx context.Context, data *schema.ResourceData,
 	_, err := client.GetEdgeClusterWithResponse(ctx, data.Id())
 
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
@@ -235,18 +251,28 @@ func resourceNsxEdgeClusterDelete(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNsxEdgeClusterUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api_client.SddcManagerClient).ApiClient
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	client := vcfClient.ApiClient
 
 	edgeClusterOk, err := client.GetEdgeClusterWithResponse(ctx, data.Id())
 
 	if err != nil {
-		return diag.FromErr(err)
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	resp, vcfErr := api_client.GetResponseAs[vcf.EdgeCluster](edgeClusterOk)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
+	}
+
+	// The passwords are applied to the nodes of the cluster before any of them is removed
+	passwords := edgeClusterPasswords(resp)
+	var diags diag.Diagnostics
+	if hasPasswordChanges(data, passwords...) && isDryRun(ctx) {
+		diags = dryRunNotValidated(fmt.Sprintf("the password update of edge cluster %s", data.Id()))
+	} else if err = updateAccountPasswords(ctx, data, vcfClient, passwords...); err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 
 	if data.HasChange("edge_node") {
@@ -272,31 +298,94 @@ func resourceNsxEdgeClusterUpdate(ctx context.Context, data *schema.ResourceData
 		if len(oldNodes) < len(newNodes) {
 			operation := expansion
 			updateSpec.Operation = operation
-			spec, err := nsx_edge_cluster.GetNsxEdgeClusterExpansionSpec(*resp.EdgeNodes, newNodes, client)
+			// The passwords of the new nodes are not part of the data if they are write-only
+			configuredNodes := utils.GetWithWriteOnly(data, "edge_node").([]interface{})
+			spec, err := nsx_edge_cluster.GetNsxEdgeClusterExpansionSpec(*resp.EdgeNodes, configuredNodes, client)
 
 			if err != nil {
-				return diag.FromErr(err)
+				return validationUtils.ConvertVcfErrorToDiag(err)
 			}
 			updateSpec.EdgeClusterExpansionSpec = spec
 			tflog.Info(ctx, "Expanding edge cluster")
 		}
 
+		if isDryRun(ctx) {
+			return append(diags, validateEdgeClusterUpdateSpec(ctx, client, data.Id(), updateSpec)...)
+		}
+
+		var clusterIds []string
+		if updateSpec.EdgeClusterExpansionSpec != nil {
+			clusterIds = edgeNodeClusterIds(updateSpec.EdgeClusterExpansionSpec.EdgeNodeSpecs)
+		}
+		ctx, unlock, err := lockEdgeCluster(ctx, vcfClient, data.Id(), clusterIds)
+		if err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
+		defer unlock()
+
 		taskRes, err := client.UpdateEdgeClusterWithResponse(ctx, data.Id(), updateSpec)
 		if err != nil {
-			return diag.FromErr(err)
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
 		task, vcfErr := api_client.GetResponseAs[vcf.Task](taskRes)
 		if vcfErr != nil {
 			api_client.LogError(vcfErr, ctx)
-			return diag.FromErr(errors.New(*vcfErr.Message))
+			return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 		}
 
-		if err = api_client.NewTaskTracker(ctx, client, *task.Id).WaitForTask(); err != nil {
-			return diag.FromErr(err)
+		if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
 		}
 	}
 
-	return nil
+	return diags
+}
+
+// edgeClusterPasswords returns the passwords of the accounts of the nodes of an edge cluster.
+func edgeClusterPasswords(edgeCluster *vcf.EdgeCluster) []accountPassword {
+	if edgeCluster.EdgeNodes == nil {
+		return nil
+	}
+	var passwords []accountPassword
+	for _, node := range *edgeCluster.EdgeNodes {
+		passwords = append(passwords,
+			accountPassword{path: "root_password", resourceType: credentials.ResourceTypeNsxEdge, resourceName: node.HostName,
+				credentialType: "SSH", username: "root"},
+			accountPassword{path: "admin_password", resourceType: credentials.ResourceTypeNsxEdge, resourceName: node.HostName,
+				credentialType: "API", username: "admin"},
+			accountPassword{path: "audit_password", resourceType: credentials.ResourceTypeNsxEdge, resourceName: node.HostName,
+				credentialType: "AUDIT", username: "audit"})
+	}
+	return passwords
+}
+
+// edgeNodeClusterIds returns the IDs of the clusters on which edge nodes are deployed.
+func edgeNodeClusterIds(nodeSpecs []vcf.NsxTEdgeNodeSpec) []string {
+	var clusterIds []string
+	for _, nodeSpec := range nodeSpecs {
+		if nodeSpec.ClusterId != nil {
+			clusterIds = append(clusterIds, *nodeSpec.ClusterId)
+		}
+	}
+	return clusterIds
+}
+
+// validateEdgeClusterUpdateSpec validates the expansion or shrinkage of an edge cluster in dry-run mode.
+func validateEdgeClusterUpdateSpec(ctx context.Context, client *vcf.ClientWithResponses, edgeClusterId string,
+	updateSpec vcf.EdgeClusterUpdateSpec) diag.Diagnostics {
+	validateResponse, err := client.ValidateEdgeClusterUpdateSpecWithResponse(ctx, edgeClusterId, updateSpec)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
+	if vcfErr != nil {
+		api_client.LogError(vcfErr, ctx)
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
+	}
+	if validationUtils.HasValidationFailed(validationResult) {
+		return validationUtils.ConvertValidationResultToDiag(validationResult)
+	}
+	return dryRunPassed(fmt.Sprintf("the update of edge cluster %s", edgeClusterId))
 }
 
 func validateClusterCreationSpec(client *vcf.ClientWithResponses, ctx context.Context, spec vcf.EdgeClusterCreationSpec) diag.Diagnostics {
@@ -308,7 +397,7 @@ func validateClusterCreationSpec(client *vcf.ClientWithResponses, ctx context.Co
 	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	if validationUtils.HasValidationFailed(validationResult) {
@@ -323,7 +412,7 @@ func validateClusterCreationSpec(client *vcf.ClientWithResponses, ctx context.Co
 		validationStatus, vcfErr := api_client.GetResponseAs[vcf.Validation](getValidationResponse)
 		if vcfErr != nil {
 			api_client.LogError(vcfErr, ctx)
-			return diag.FromErr(errors.New(*vcfErr.Message))
+			return validationUtils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 		}
 
 		if validationUtils.HaveValidationChecksFinished(*validationStatus.ValidationChecks) {
@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -81,7 +80,7 @@ func resourceResourceExternalCertificateCreate(ctx context.Context, data *schema
 	csrID := data.Get("csr_id").(string)
 	csrIdComponents := strings.Split(csrID, ":")
 	if len(csrIdComponents) != 5 {
-		return diag.FromErr(fmt.Errorf("CSR ID invalid"))
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("CSR ID invalid"))
 	}
 
 	domainID := csrIdComponents[1]
@@ -106,7 +105,7 @@ func resourceResourceExternalCertificateCreate(ctx context.Context, data *schema
 			CertificateChain: &certificateChain,
 		}
 	} else {
-		return diag.FromErr(fmt.Errorf("no certificate_chain or (ca_certificate, resource_certificate) defined"))
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("no certificate_chain or (ca_certificate, resource_certificate) defined"))
 	}
 
 	resourceCertificateSpecs := []vcf.ResourceCertificateSpec{resourceCertificateSpec}
@@ -118,15 +117,15 @@ func resourceResourceExternalCertificateCreate(ctx context.Context, data *schema
 
 	responseAcc, err := apiClient.ReplaceResourceCertificatesWithResponse(ctx, domainID, resourceCertificateSpecs)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](responseAcc)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	data.SetId("ext_cert:" + domainID + ":" + resourceType + ":" + *task.Id)
 
@@ -139,7 +138,7 @@ func resourceResourceExternalCertificateRead(ctx context.Context, data *schema.R
 	csrID := data.Get("csr_id").(string)
 	csrIdComponents := strings.Split(csrID, ":")
 	if len(csrIdComponents) != 5 {
-		return diag.FromErr(fmt.Errorf("CSR ID invalid"))
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("CSR ID invalid"))
 	}
 
 	domainID := csrIdComponents[1]
@@ -147,7 +146,7 @@ func resourceResourceExternalCertificateRead(ctx context.Context, data *schema.R
 
 	cert, err := certificates.ReadCertificate(ctx, apiClient, domainID, resourceType)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	flattenedCert := certificates.FlattenCertificate(*cert)
@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,6 +20,9 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceHost() *schema.Resource {
@@ -33,6 +38,9 @@ func ResourceHost() *schema.Resource {
 			Create: schema.DefaultTimeout(12 * time.Hour),
 			Delete: schema.DefaultTimeout(1 * time.Hour),
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			utils.RequireSecrets("password"),
+		},
 		Schema: map[string]*schema.Schema{
 			"fqdn": {
 				Type:        schema.TypeString,
@@ -66,10 +74,12 @@ func ResourceHost() *schema.Resource {
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Password to authenticate to the ESXi host",
+				Description: "Password to authenticate to the ESXi host. Either password or password_wo is required",
 			},
+			"password_wo":         utils.WriteOnlySchema("password", "Password to authenticate to the ESXi host", nil),
+			"password_wo_version": utils.WriteOnlyVersionSchema("password", false),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -97,9 +107,7 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		commissionSpec.Username = username.(string)
 	}
 
-	if password, ok := d.GetOk("password"); ok {
-		commissionSpec.Password = password.(string)
-	}
+	commissionSpec.Password = utils.GetSecret(d, "password")
 
 	if networkPoolId, ok := d.GetOk("network_pool_id"); ok {
 		commissionSpec.NetworkPoolId = networkPoolId.(string)
@@ -107,41 +115,39 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	if networkPoolName, ok := d.GetOk("network_pool_name"); ok {
 		if commissionSpec.NetworkPoolId != "" {
-			return diag.FromErr(errors.New("you cannot set network_pool_id and network_pool_name at the same time"))
+			return validationutils.ConvertVcfErrorToDiag(errors.New("you cannot set network_pool_id and network_pool_name at the same time"))
 		}
 
 		networkPool, err := getNetworkPool(networkPoolName.(string), apiClient, ctx)
 
 		if err != nil {
-			return diag.FromErr(err)
+			return validationutils.ConvertVcfErrorToDiag(err)
 		}
 
 		commissionSpec.NetworkPoolId = *networkPool.Id
 	}
 
-	accepted, err := apiClient.CommissionHostsWithResponse(ctx, []vcf.HostCommissionSpec{commissionSpec})
-	if err != nil {
-		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+	if isDryRun(ctx) {
+		return validateHostCommission(ctx, vcfClient, commissionSpec)
 	}
-	task, vcfErr := api_client.GetResponseAs[vcf.Task](accepted)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("%s commissionSpec commission initiated. waiting for task id = %s",
-		commissionSpec.Fqdn, *task.Id))
 
-	if err = api_client.NewTaskTrackerWithCustomPollingInterval(ctx, apiClient, *task.Id, time.Second*5).WaitForTask(); err != nil {
-		return diag.FromErr(err)
-	}
-	hostId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, *task.Id, "Esxi")
+	taskId, err := findCreationTask(ctx, vcfClient, hostTaskResource(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
+	if taskId == "" {
+		if taskId, err = commissionHost(ctx, vcfClient, commissionSpec); err != nil {
+			return validationutils.ConvertVcfErrorToDiag(err)
+		}
 
-	d.SetId(hostId)
+		tflog.Info(ctx, fmt.Sprintf("%s commissionSpec commission initiated. waiting for task id = %s",
+			commissionSpec.Fqdn, taskId))
+	}
+
+	tracker := vcfClient.NewSharedTaskTracker(ctx, taskId, time.Second*5)
+	if diags := awaitResourceCreation(ctx, d, tracker, vcfClient, taskId, hostTaskResource(d)); diags != nil {
+		return diags
+	}
 
 	return resourceHostRead(ctx, d, meta)
 }
@@ -152,12 +158,12 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	hostResponse, err := apiClient.GetHostWithResponse(ctx, d.Id())
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	host, vcfErr := api_client.GetResponseAs[vcf.Host](hostResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	_ = d.Set("network_pool_id", host.Networkpool.Id)
@@ -167,34 +173,59 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	params := &vcf.GetCredentialsParams{
 		ResourceName: host.Fqdn,
 	}
-	getCredentialsResponse, err := apiClient.GetCredentialsWithResponse(ctx, params)
+	credential, err := api_client.FindInPages(ctx, func(pageNumber int) (api_client.Response, error) {
+		params.PageNumber = utils.ToStringPointer(strconv.Itoa(pageNumber))
+		return apiClient.GetCredentialsWithResponse(ctx, params)
+	}, func(credential vcf.Credential) bool {
+		// we're interested in the SSH credentials, not service account
+		return *credential.AccountType == "USER" && *credential.CredentialType == "SSH"
+	})
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
-	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfCredential](getCredentialsResponse)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
-	for _, credential := range *page.Elements {
-		// we're interested in the SSH credentials, not service account
-		if *credential.AccountType != "USER" || *credential.CredentialType != "SSH" {
-			continue
-		}
+	if credential != nil {
+		hasUsername := d.Get("username").(string) != ""
 		if credential.Resource.ResourceId != *host.Id {
-			return diag.FromErr(fmt.Errorf("hostId doesn't match host FQDN when requesting credentials"))
+			return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("hostId doesn't match host FQDN when requesting credentials"))
 		}
 		_ = d.Set("username", *credential.Username)
-		_ = d.Set("password", credential.Password)
+		// Only imported hosts, which have no username yet, and hosts whose password is in the
+		// state read the password, hosts commissioned with password_wo keep it out of the state
+		if d.Get("password").(string) != "" || !hasUsername {
+			_ = d.Set("password", credential.Password)
+		}
 	}
 
 	return nil
 }
 
-// There is no update method for commissioned hosts.
+// hostTaskResource describes the host of a vcf_host among the hosts commissioned by a task.
+func hostTaskResource(d *schema.ResourceData) taskResource {
+	return taskResource{Type: "Esxi", Fqdn: d.Get("fqdn").(string), exists: hostExists, adoptFailed: true}
+}
+
+func hostExists(ctx context.Context, vcfClient *api_client.SddcManagerClient, id string) bool {
+	hostResponse, err := vcfClient.ApiClient.GetHostWithResponse(ctx, id)
+	return err == nil && hostResponse.StatusCode() == http.StatusOK
+}
+
+// There is no update method for commissioned hosts, only a new password is applied to the host.
 func resourceHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return resourceDomainRead(ctx, d, meta)
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	err := updateAccountPasswords(ctx, d, vcfClient, accountPassword{
+		path:           "password",
+		resourceType:   credentials.ResourceTypeEsxi,
+		resourceName:   d.Get("fqdn").(string),
+		credentialType: "SSH",
+		username:       d.Get("username").(string),
+	})
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+
+	return resourceHostRead(ctx, d, meta)
 }
 
 func resourceHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -207,42 +238,77 @@ func resourceHostDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	accepted, err := apiClient.DecommissionHostsWithResponse(ctx, []vcf.HostDecommissionSpec{decommissionSpec})
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	task, vcfErr := api_client.GetResponseAs[vcf.Task](accepted)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	log.Printf("%s %s: Decommission task initiated. Task id %s",
 		d.Get("fqdn").(string), d.Id(), *task.Id)
-	if err = api_client.NewTaskTracker(ctx, apiClient, *task.Id).WaitForTask(); err != nil {
-		return diag.FromErr(err)
+	if err = vcfClient.NewTaskTracker(ctx, *task.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return nil
 }
 
 func getNetworkPool(name string, client *vcf.ClientWithResponses, ctx context.Context) (*vcf.NetworkPool, error) {
-	ok, err := client.GetNetworkPoolWithResponse(ctx)
-
+	pool, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return client.GetNetworkPoolWithResponse(ctx)
+	}, func(pool vcf.NetworkPool) bool {
+		return pool.Name == name
+	})
 	if err != nil {
 		return nil, err
 	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfNetworkPool](ok)
+	if pool == nil {
+		return nil, fmt.Errorf("network pool %s not found", name)
+	}
+
+	return pool, nil
+}
+
+// validateHostCommission validates the commissioning of a host in dry-run mode.
+func validateHostCommission(ctx context.Context, vcfClient *api_client.SddcManagerClient, commissionSpec vcf.HostCommissionSpec) diag.Diagnostics {
+	validateResponse, err := vcfClient.ApiClient.ValidateHostCommissionSpecWithResponse(ctx, []vcf.HostCommissionSpec{commissionSpec})
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	validationResult, vcfErr := api_client.GetResponseAs[vcf.Validation](validateResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
+	}
+	if validationResult == nil || validationResult.Id == nil {
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("failed to validate the commissioning of host %s: %s",
+			commissionSpec.Fqdn, validateResponse.Status()))
 	}
 
-	if page != nil {
-		for _, pool := range *page.Elements {
-			if pool.Name == name {
-				return &pool, nil
-			}
-		}
+	if validationutils.HasValidationFailed(validationResult) {
+		return validationutils.ConvertValidationResultToDiag(validationResult)
+	}
+	if err = vcfClient.NewHostCommissionValidationTracker(ctx, *validationResult.Id).WaitForTask(); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
-	return nil, fmt.Errorf("network pool %s not found", name)
+	getValidationResponse, err := vcfClient.ApiClient.GetHostCommissionValidationByIDWithResponse(ctx, *validationResult.Id)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	validationResult, vcfErr = api_client.GetResponseAs[vcf.Validation](getValidationResponse)
+	if vcfErr != nil {
+		api_client.LogError(vcfErr, ctx)
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
+	}
+	if validationResult == nil {
+		return validationutils.ConvertVcfErrorToDiag(fmt.Errorf("failed to retrieve the validation of the commissioning of host %s: %s",
+			commissionSpec.Fqdn, getValidationResponse.Status()))
+	}
+	if validationutils.HasValidationFailed(validationResult) {
+		return validationutils.ConvertValidationResultToDiag(validationResult)
+	}
+	return dryRunPassed(fmt.Sprintf("the commissioning of host %s", commissionSpec.Fqdn))
 }
@@ -6,15 +6,25 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 )
 
@@ -158,3 +168,261 @@ func testCheckVcfHostDestroy(_ *terraform.State) error {
 	// Found the host
 	return nil
 }
+
+// withHostCommissionWindow shortens the time hosts are collected to be commissioned together.
+func withHostCommissionWindow(t *testing.T, window time.Duration) {
+	defaultWindow := hostCommissionWindow
+	hostCommissionWindow = window
+	t.Cleanup(func() { hostCommissionWindow = defaultWindow })
+}
+
+// createHosts creates a vcf_host for each of the FQDNs in parallel, in the network pool with the
+// ID at the same index.
+func createHosts(t *testing.T, client *api_client.SddcManagerClient, networkPoolIds, fqdns []string) ([]*schema.ResourceData, []diag.Diagnostics) {
+	data := make([]*schema.ResourceData, len(fqdns))
+	diags := make([]diag.Diagnostics, len(fqdns))
+	var wg sync.WaitGroup
+	for i, fqdn := range fqdns {
+		data[i] = schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+			"fqdn":            fqdn,
+			"username":        "root",
+			"password":        "S@mpleL0ngP@ss123!",
+			"network_pool_id": networkPoolIds[i],
+			"storage_type":    "VSAN",
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			diags[i] = resourceHostCreate(context.Background(), data[i], client)
+		}()
+	}
+	wg.Wait()
+	return data, diags
+}
+
+func TestResourceHostCreate(t *testing.T) {
+	withHostCommissionWindow(t, 10*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	networkPoolId := server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	diags := resourceHostCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	host, ok := server.HostById(data.Id())
+	assert.True(t, ok, "host %q was not commissioned", data.Id())
+	assert.Equal(t, "esxi-1.vrack.vsphere.local", *host.Fqdn)
+	assert.Equal(t, networkPoolId, data.Get("network_pool_id"))
+	assert.Equal(t, "UNASSIGNED_USEABLE", data.Get("status"))
+}
+
+func TestResourceHostCreate_writeOnlyPassword(t *testing.T) {
+	withHostCommissionWindow(t, 10*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password_wo":       "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	diags := resourceHostCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	var commissionSpecs []vcf.HostCommissionSpec
+	for _, request := range server.Requests() {
+		if request.Method == http.MethodPost && request.Path == "/v1/hosts" {
+			assert.NoError(t, json.Unmarshal(request.Body, &commissionSpecs))
+		}
+	}
+	if assert.Len(t, commissionSpecs, 1) {
+		assert.Equal(t, "S@mpleL0ngP@ss123!", commissionSpecs[0].Password)
+	}
+	// The password is not read back into the state
+	assert.Empty(t, data.Get("password"))
+}
+
+func TestResourceHostUpdate_passwordVersion(t *testing.T) {
+	withHostCommissionWindow(t, 10*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	config := map[string]interface{}{
+		"fqdn":                "esxi-1.vrack.vsphere.local",
+		"username":            "root",
+		"password_wo":         "S@mpleL0ngP@ss123!",
+		"password_wo_version": 1,
+		"network_pool_name":   "eng-pool",
+		"storage_type":        "VSAN",
+	}
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, config)
+	diags := resourceHostCreate(context.Background(), data, client)
+	assert.False(t, diags.HasError(), diags)
+
+	// An update without a new version leaves the password as it is
+	diags = resourceHostUpdate(context.Background(), ResourceHost().Data(data.State()), client)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/credentials"))
+
+	config["password_wo"] = "An0therL0ngP@ss123!"
+	config["password_wo_version"] = 2
+	updated := schema.TestResourceDataRaw(t, ResourceHost().Schema, config)
+	updated.SetId(data.Id())
+
+	diags = resourceHostUpdate(context.Background(), updated, client)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, server.RequestCount(http.MethodPatch, "/v1/credentials"))
+	credential, ok := server.Credential("esxi-1.vrack.vsphere.local", "root")
+	if assert.True(t, ok) {
+		assert.Equal(t, "An0therL0ngP@ss123!", *credential.Password)
+	}
+	assert.Empty(t, updated.Get("password"))
+}
+
+func TestResourceHostCreate_interrupted(t *testing.T) {
+	withHostCommissionWindow(t, 10*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	// The task runs longer than the first run waits for it
+	server.ScriptNextTask(append(slices.Repeat([]string{fake_server.TaskStatusInProgress}, 20), fake_server.TaskStatusSuccessful)...)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	diags := resourceHostCreate(ctx, data, client)
+
+	assert.True(t, diags.HasError(), diags)
+	assert.Empty(t, data.Id(), "the host must not be saved in the state")
+
+	// The next run re-attaches to the running task and adopts the host
+	data = schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+	diags = resourceHostCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	host, ok := server.HostById(data.Id())
+	if assert.True(t, ok, "host %q was not adopted", data.Id()) {
+		assert.Equal(t, "esxi-1.vrack.vsphere.local", *host.Fqdn)
+	}
+	assert.Equal(t, "UNASSIGNED_USEABLE", data.Get("status"))
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/hosts"))
+}
+
+func TestResourceHostCreate_failedTask(t *testing.T) {
+	withHostCommissionWindow(t, 10*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	networkPoolId := server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	server.ScriptNextTask(fake_server.TaskStatusFailed)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":            "esxi-1.vrack.vsphere.local",
+		"username":        "root",
+		"password":        "S@mpleL0ngP@ss123!",
+		"network_pool_id": networkPoolId,
+		"storage_type":    "VSAN",
+	})
+
+	diags := resourceHostCreate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, fake_server.TaskStatusFailed)
+	assert.Empty(t, data.Id())
+}
+
+func TestResourceHostCreate_unknownNetworkPool(t *testing.T) {
+	withHostCommissionWindow(t, 10*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+
+	data := schema.TestResourceDataRaw(t, ResourceHost().Schema, map[string]interface{}{
+		"fqdn":              "esxi-1.vrack.vsphere.local",
+		"username":          "root",
+		"password":          "S@mpleL0ngP@ss123!",
+		"network_pool_name": "eng-pool",
+		"storage_type":      "VSAN",
+	})
+
+	diags := resourceHostCreate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "network pool eng-pool not found", diags[0].Summary)
+	assert.Zero(t, server.RequestCount(http.MethodPost, "/v1/hosts"))
+}
+
+func TestResourceHostCreate_batch(t *testing.T) {
+	withHostCommissionWindow(t, 200*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	networkPoolId := server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	fqdns := []string{"esxi-1.vrack.vsphere.local", "esxi-2.vrack.vsphere.local", "esxi-3.vrack.vsphere.local"}
+
+	data, diags := createHosts(t, client, []string{networkPoolId, networkPoolId, networkPoolId}, fqdns)
+
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/hosts"))
+	for i, fqdn := range fqdns {
+		assert.False(t, diags[i].HasError(), diags[i])
+		host, ok := server.HostById(data[i].Id())
+		if assert.True(t, ok, "host %q was not commissioned", data[i].Id()) {
+			assert.Equal(t, fqdn, *host.Fqdn)
+		}
+	}
+}
+
+func TestResourceHostCreate_batchFailedHost(t *testing.T) {
+	withHostCommissionWindow(t, 200*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	networkPoolId := server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+	server.FailHostCommission("esxi-2.vrack.vsphere.local")
+
+	data, diags := createHosts(t, client, []string{networkPoolId, networkPoolId},
+		[]string{"esxi-1.vrack.vsphere.local", "esxi-2.vrack.vsphere.local"})
+
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/hosts"))
+	assert.False(t, diags[0].HasError(), diags[0])
+	host, ok := server.HostById(data[0].Id())
+	if assert.True(t, ok, "host %q was not commissioned", data[0].Id()) {
+		assert.Equal(t, "esxi-1.vrack.vsphere.local", *host.Fqdn)
+	}
+	assert.True(t, diags[1].HasError())
+	assert.Empty(t, data[1].Id())
+}
+
+func TestResourceHostCreate_batchRejected(t *testing.T) {
+	withHostCommissionWindow(t, 200*time.Millisecond)
+	server, client := testSddcManagerClient(t)
+	networkPoolId := server.AddNetworkPool(vcf.NetworkPool{Name: "eng-pool"})
+
+	data, diags := createHosts(t, client, []string{networkPoolId, "unknown-pool"},
+		[]string{"esxi-1.vrack.vsphere.local", "esxi-2.vrack.vsphere.local"})
+
+	// The batch is rejected as a whole, the hosts are then commissioned one by one
+	assert.Equal(t, 3, server.RequestCount(http.MethodPost, "/v1/hosts"))
+	assert.False(t, diags[0].HasError(), diags[0])
+	host, ok := server.HostById(data[0].Id())
+	if assert.True(t, ok, "host %q was not commissioned", data[0].Id()) {
+		assert.Equal(t, "esxi-1.vrack.vsphere.local", *host.Fqdn)
+	}
+	assert.True(t, diags[1].HasError())
+	assert.Empty(t, data[1].Id())
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -32,6 +33,14 @@ func ResourceVcfInstance() *schema.Resource {
 			Create: schema.DefaultTimeout(15 * time.Hour), // it takes a while
 		},
 		Schema: resourceVcfInstanceSchema(),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			utils.RequireSecrets("host.credentials.password", "nsx.root_nsx_manager_password",
+				"sddc_manager.root_user_password", "sddc_manager.ssh_password", "vcenter.root_vcenter_password"),
+			utils.ConflictingSecrets("nsx.nsx_admin_password", "nsx.nsx_audit_password", "sddc_manager.local_user_password",
+				"automation.admin_user_password", "operations.admin_user_password", "operations.node.root_user_password",
+				"operations_collector.root_user_password", "operations_fleet_management.root_user_password",
+				"operations_fleet_management.admin_user_password"),
+		},
 	}
 }
 
@@ -124,8 +133,8 @@ func buildSddcSpec(data *schema.ResourceData) *installer.SddcSpec {
 	if dvsSpecs, ok := data.GetOk("dvs"); ok {
 		sddcSpec.DvsSpecs = sddc.GetDvsSpecsFromSchema(dvsSpecs.([]interface{}))
 	}
-	if hostSpecs, ok := data.GetOk("host"); ok {
-		sddcSpec.HostSpecs = sddc.GetSddcHostSpecsFromSchema(hostSpecs.([]interface{}))
+	if _, ok := data.GetOk("host"); ok {
+		sddcSpec.HostSpecs = sddc.GetSddcHostSpecsFromSchema(utils.GetWithWriteOnly(data, "host").([]interface{}))
 	}
 	if managementPoolName, ok := data.GetOk("management_pool_name"); ok {
 		sddcSpec.ManagementPoolName = utils.ToStringPointer(managementPoolName)
@@ -133,8 +142,8 @@ func buildSddcSpec(data *schema.ResourceData) *installer.SddcSpec {
 	if networkSpecs, ok := data.GetOk("network"); ok {
 		sddcSpec.NetworkSpecs = sddc.GetNetworkSpecsBindingFromSchema(networkSpecs.([]interface{}))
 	}
-	if nsxSpec, ok := data.GetOk("nsx"); ok {
-		sddcSpec.NsxtSpec = sddc.GetNsxSpecFromSchema(nsxSpec.([]interface{}))
+	if _, ok := data.GetOk("nsx"); ok {
+		sddcSpec.NsxtSpec = sddc.GetNsxSpecFromSchema(utils.GetWithWriteOnly(data, "nsx").([]interface{}))
 	}
 	if ntpServers, ok := data.GetOk("ntp_servers"); ok {
 		ntpServersValue := utils.ToStringSlice(ntpServers.([]interface{}))
@@ -143,8 +152,8 @@ func buildSddcSpec(data *schema.ResourceData) *installer.SddcSpec {
 	if sddcID, ok := data.GetOk("instance_id"); ok {
 		sddcSpec.SddcId = sddcID.(string)
 	}
-	if sddcManagerSpec, ok := data.GetOk("sddc_manager"); ok {
-		sddcSpec.SddcManagerSpec = sddc.GetSddcManagerSpecFromSchema(sddcManagerSpec.([]interface{}))
+	if _, ok := data.GetOk("sddc_manager"); ok {
+		sddcSpec.SddcManagerSpec = sddc.GetSddcManagerSpecFromSchema(utils.GetWithWriteOnly(data, "sddc_manager").([]interface{}))
 	}
 	if securitySpec, ok := data.GetOk("security"); ok {
 		sddcSpec.SecuritySpec = sddc.GetSecuritySpecSchema(securitySpec.([]interface{}))
@@ -152,28 +161,28 @@ func buildSddcSpec(data *schema.ResourceData) *installer.SddcSpec {
 	if skipEsxThumbPrintValidation, ok := data.GetOk("skip_esx_thumbprint_validation"); ok {
 		sddcSpec.SkipEsxThumbprintValidation = utils.ToBoolPointer(skipEsxThumbPrintValidation)
 	}
-	if vcenterSpec, ok := data.GetOk("vcenter"); ok {
-		if spec := sddc.GetVcenterSpecFromSchema(vcenterSpec.([]interface{})); spec != nil {
+	if _, ok := data.GetOk("vcenter"); ok {
+		if spec := sddc.GetVcenterSpecFromSchema(utils.GetWithWriteOnly(data, "vcenter").([]interface{})); spec != nil {
 			sddcSpec.VcenterSpec = *spec
 		}
 	}
-	if automationSpec, ok := data.GetOk("automation"); ok {
-		if spec := sddc.GetVcfAutomationSpecFromSchema(automationSpec.([]interface{})); spec != nil {
+	if _, ok := data.GetOk("automation"); ok {
+		if spec := sddc.GetVcfAutomationSpecFromSchema(utils.GetWithWriteOnly(data, "automation").([]interface{})); spec != nil {
 			sddcSpec.VcfAutomationSpec = spec
 		}
 	}
-	if operationsSpec, ok := data.GetOk("operations"); ok {
-		if spec := sddc.GetVcfOperationsSpecFromSchema(operationsSpec.([]interface{})); spec != nil {
+	if _, ok := data.GetOk("operations"); ok {
+		if spec := sddc.GetVcfOperationsSpecFromSchema(utils.GetWithWriteOnly(data, "operations").([]interface{})); spec != nil {
 			sddcSpec.VcfOperationsSpec = spec
 		}
 	}
-	if operationsCollectorSpec, ok := data.GetOk("operations_collector"); ok {
-		if spec := sddc.GetVcfOperationsCollectorSpecFromSchema(operationsCollectorSpec.([]interface{})); spec != nil {
+	if _, ok := data.GetOk("operations_collector"); ok {
+		if spec := sddc.GetVcfOperationsCollectorSpecFromSchema(utils.GetWithWriteOnly(data, "operations_collector").([]interface{})); spec != nil {
 			sddcSpec.VcfOperationsCollectorSpec = spec
 		}
 	}
-	if operationsFleetManagementSpec, ok := data.GetOk("operations_fleet_management"); ok {
-		if spec := sddc.GetVcfOperationsFleetManagementSpecFromSchema(operationsFleetManagementSpec.([]interface{})); spec != nil {
+	if _, ok := data.GetOk("operations_fleet_management"); ok {
+		if spec := sddc.GetVcfOperationsFleetManagementSpecFromSchema(utils.GetWithWriteOnly(data, "operations_fleet_management").([]interface{})); spec != nil {
 			sddcSpec.VcfOperationsFleetManagementSpec = spec
 		}
 	}
@@ -191,29 +200,53 @@ func resourceVcfInstanceCreate(ctx context.Context, data *schema.ResourceData, m
 	bringUpInfo, err := getLastBringUp(ctx, client)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
-	bringUpID, diags := invokeBringupWorkflow(ctx, client, sddcSpec, bringUpInfo)
-	if diags != nil {
-		return diags
+	var bringUpID string
+	if bringUpInfo != nil && isBringUpRunning(bringUpInfo) && !isDryRun(ctx) {
+		// The bring-up of an earlier run which has been interrupted is still running
+		bringUpID = *bringUpInfo.Id
+		tflog.Info(ctx, fmt.Sprintf("Re-attaching to running Bring-Up workflow with ID %s", bringUpID))
+	} else {
+		var diags diag.Diagnostics
+		if bringUpID, diags = invokeBringupWorkflow(ctx, client, sddcSpec, bringUpInfo); diags != nil {
+			return diags
+		}
 	}
 
-	diags = waitForBringupProcess(ctx, bringUpID, client)
-	if diags != nil {
-		return diags
+	err = client.NewSddcTaskTracker(ctx, bringUpID).WaitForTask()
+
+	// The bring-up keeps running on the installer, nothing is saved in the state and the next
+	// run re-attaches to the bring-up rather than starting it again
+	var interrupted *api_client.TaskInterruptedError
+	if errors.As(err, &interrupted) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The bring-up with ID %s is still in progress", bringUpID),
+			Detail: fmt.Sprintf("%s.\n\nThe next Terraform run re-attaches to the bring-up while it is still running "+
+				"and adopts the instance once it has completed.", err),
+		}}
+	}
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 
 	return resourceVcfInstanceRead(ctx, data, meta)
 }
 
+// isBringUpRunning reports whether a bring-up is still running on the installer.
+func isBringUpRunning(bringUp *installer.SddcTask) bool {
+	return bringUp.Status != nil && strings.EqualFold(*bringUp.Status, "IN_PROGRESS")
+}
+
 func resourceVcfInstanceRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.InstallerClient)
 
 	bringUpInfo, err := getLastBringUp(ctx, client)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	bringupId := bringUpInfo.Id
 
@@ -243,7 +276,7 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.InstallerClie
 
 		res, err := client.ApiClient.RetrySddcWithResponse(ctx, bringUpId, nil, *sddcSpec)
 		if err != nil {
-			return "", diag.FromErr(err)
+			return "", validationutils.ConvertVcfErrorToDiag(err)
 		}
 		sddcTask, vcfErr := api_client.GetResponseAs[installer.SddcTask](res)
 		if vcfErr != nil {
@@ -259,11 +292,11 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.InstallerClie
 
 		res, err := client.ApiClient.DeploySddcWithResponse(ctx, nil, *sddcSpec)
 		if err != nil {
-			return "", diag.FromErr(err)
+			return "", validationutils.ConvertVcfErrorToDiag(err)
 		}
 		sddcTask, vcfErr := api_client.GetResponseAs[installer.SddcTask](res)
 		if err != nil {
-			return "", diag.FromErr(err)
+			return "", validationutils.ConvertVcfErrorToDiag(err)
 		}
 		if vcfErr != nil {
 			api_client.LogError(vcfErr, ctx)
@@ -276,28 +309,6 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.InstallerClie
 	return bringUpId, nil
 }
 
-func waitForBringupProcess(ctx context.Context, bringUpID string, client *api_client.InstallerClient) diag.Diagnostics {
-	for {
-		task, err := getBringUp(ctx, bringUpID, client)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		if *task.Status == "IN_PROGRESS" {
-			time.Sleep(20 * time.Second)
-			continue
-		}
-
-		if *task.Status == "COMPLETED_WITH_FAILURE" {
-			err := fmt.Errorf("task with ID = %s , Name: %q is in state %s", bringUpID, *task.Name, *task.Status)
-			logFailedTask(ctx, task)
-			return diag.FromErr(err)
-		}
-
-		return nil
-	}
-}
-
 func getLastBringUp(ctx context.Context, client *api_client.InstallerClient) (*installer.SddcTask, error) {
 	retrieveAllSddcsResp, err := client.ApiClient.GetSddcTasksWithResponse(ctx)
 	if err != nil {
@@ -306,7 +317,7 @@ func getLastBringUp(ctx context.Context, client *api_client.InstallerClient) (*i
 	page, vcfErr := api_client.GetResponseAs[installer.PageOfSddcTask](retrieveAllSddcsResp)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return nil, api_client.NewApiError(vcfErr)
 	}
 	if page != nil && len(*page.Elements) > 0 {
 		elements := *page.Elements
@@ -318,12 +329,12 @@ func getLastBringUp(ctx context.Context, client *api_client.InstallerClient) (*i
 func validateBringupSpec(ctx context.Context, client *api_client.InstallerClient, sddcSpec *installer.SddcSpec) diag.Diagnostics {
 	validateSpecRes, err := client.ApiClient.ValidateSddcSpecWithResponse(ctx, *sddcSpec)
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	validationResult, vcfErr := api_client.GetResponseAs[installer.Validation](validateSpecRes)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	vcfValidationResult := api_client.ConvertToVcfValidation(*validationResult)
@@ -331,51 +342,26 @@ func validateBringupSpec(ctx context.Context, client *api_client.InstallerClient
 	if validationutils.HasValidationFailed(&vcfValidationResult) {
 		return validationutils.ConvertValidationResultToDiag(&vcfValidationResult)
 	}
-	for {
-		getValidationResponse, err := client.ApiClient.GetSddcSpecValidationWithResponse(ctx, *validationResult.Id)
-		if err != nil {
-			return validationutils.ConvertVcfErrorToDiag(err)
-		}
-		validationResult, vcfErr = api_client.GetResponseAs[installer.Validation](getValidationResponse)
-		if vcfErr != nil {
-			api_client.LogError(vcfErr, ctx)
-			return diag.FromErr(errors.New(*vcfErr.Message))
-		}
-		vcfValidationResult = api_client.ConvertToVcfValidation(*validationResult)
-		if validationutils.HaveValidationChecksFinished(*vcfValidationResult.ValidationChecks) {
-			break
-		}
-		time.Sleep(10 * time.Second)
-	}
-	if err != nil {
+	if err = client.NewValidationTracker(ctx, *validationResult.Id).WaitForTask(); err != nil {
 		return validationutils.ConvertVcfErrorToDiag(err)
 	}
-	if validationutils.HasValidationFailed(&vcfValidationResult) {
-		return validationutils.ConvertValidationResultToDiag(&vcfValidationResult)
-	}
-
-	return nil
-}
 
-func getBringUp(ctx context.Context, bringupId string, client *api_client.InstallerClient) (*installer.SddcTask, error) {
-	retrieveSddcResponse, err := client.ApiClient.GetSddcTaskByIDWithResponse(ctx, bringupId)
+	getValidationResponse, err := client.ApiClient.GetSddcSpecValidationWithResponse(ctx, *validationResult.Id)
 	if err != nil {
-		return nil, err
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
-	sddcTask, vcfErr := api_client.GetResponseAs[installer.SddcTask](retrieveSddcResponse)
+	validationResult, vcfErr = api_client.GetResponseAs[installer.Validation](getValidationResponse)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return nil, errors.New(*vcfErr.Message)
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
-	return sddcTask, nil
-}
-
-func logFailedTask(ctx context.Context, task *installer.SddcTask) {
-	tflog.Error(ctx, fmt.Sprintf("task with ID = %s, Name: %q is in state %s", *task.Id, *task.Name, *task.Status))
-
-	if task.SddcSubTasks != nil {
-		for _, subtask := range *task.SddcSubTasks {
-			tflog.Error(ctx, fmt.Sprintf("subtask %q is in state %s", *subtask.Name, *subtask.Status))
-		}
+	vcfValidationResult = api_client.ConvertToVcfValidation(*validationResult)
+	if validationutils.HasValidationFailed(&vcfValidationResult) {
+		return validationutils.ConvertValidationResultToDiag(&vcfValidationResult)
+	}
+	if isDryRun(ctx) {
+		return dryRunPassed(fmt.Sprintf("the bring-up of instance %s", sddcSpec.SddcId))
 	}
+
+	return nil
 }
@@ -7,14 +7,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client/fake_server"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	"github.com/vmware/vcf-sdk-go/installer"
@@ -569,3 +573,91 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 	assert.Equal(t, "LOADBALANCE_SRCID", (*(*sddcSpec.DvsSpecs)[0].NsxTeamings)[0].Policy)
 	assert.Equal(t, utils.ToStringPointer("ENS_INTERRUPT"), (*sddcSpec.DvsSpecs)[0].NsxtSwitchConfig.HostSwitchOperationalMode)
 }
+
+func TestResourceVcfInstanceCreate(t *testing.T) {
+	server, client := testInstallerClient(t)
+	data := schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), testVcfInstanceInput())
+
+	diags := resourceVcfInstanceCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	bringup, ok := server.SddcTask(data.Id())
+	assert.True(t, ok, "bring-up %q was not started", data.Id())
+	assert.Equal(t, fake_server.SddcStatusSuccessful, *bringup.Status)
+	assert.Equal(t, fake_server.SddcStatusSuccessful, data.Get("status"))
+}
+
+func TestResourceVcfInstanceCreate_failedValidation(t *testing.T) {
+	server, client := testInstallerClient(t)
+	server.ScriptNextSddcValidation(fake_server.ValidationFailed)
+	data := schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), testVcfInstanceInput())
+
+	diags := resourceVcfInstanceCreate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Zero(t, server.RequestCount(http.MethodPost, "/v1/sddcs"))
+}
+
+func TestResourceVcfInstanceCreate_failedBringup(t *testing.T) {
+	server, client := testInstallerClient(t)
+	server.ScriptNextSddcTask(fake_server.SddcStatusFailed)
+	data := schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), testVcfInstanceInput())
+
+	diags := resourceVcfInstanceCreate(context.Background(), data, client)
+
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, fake_server.SddcStatusFailed)
+	assert.Empty(t, data.Id())
+}
+
+func TestResourceVcfInstanceCreate_interrupted(t *testing.T) {
+	server, client := testInstallerClient(t)
+	// The bring-up runs longer than the first run waits for it
+	server.ScriptNextSddcTask(append(slices.Repeat([]string{fake_server.SddcStatusInProgress}, 20), fake_server.SddcStatusSuccessful)...)
+	data := schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), testVcfInstanceInput())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	diags := resourceVcfInstanceCreate(ctx, data, client)
+
+	// The apply fails and names the bring-up, which keeps running
+	if assert.True(t, diags.HasError(), diags) {
+		assert.Contains(t, diags[0].Summary, "sddc-")
+	}
+	assert.Empty(t, data.Id(), "the instance must not be saved in the state")
+
+	// The next run re-attaches to the running bring-up and adopts the instance
+	data = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), testVcfInstanceInput())
+	diags = resourceVcfInstanceCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.NotEmpty(t, data.Id())
+	assert.Equal(t, fake_server.SddcStatusSuccessful, data.Get("status"))
+	assert.Equal(t, 1, server.RequestCount(http.MethodPost, "/v1/sddcs"))
+	assert.Zero(t, server.RequestCount(http.MethodPatch, "/v1/sddcs/"+data.Id()))
+}
+
+func TestResourceVcfInstanceCreate_retryFailedBringup(t *testing.T) {
+	server, client := testInstallerClient(t)
+	bringupId := server.AddSddcTask(installer.SddcTask{
+		Status: utils.ToStringPointer(fake_server.SddcStatusFailed),
+	})
+	data := schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), testVcfInstanceInput())
+
+	diags := resourceVcfInstanceCreate(context.Background(), data, client)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, bringupId, data.Id())
+	assert.Equal(t, 1, server.RequestCount(http.MethodPatch, "/v1/sddcs/"+bringupId))
+	assert.Zero(t, server.RequestCount(http.MethodPost, "/v1/sddcs"))
+}
+
+// testVcfInstanceInput returns the configuration of the instance deployed on the fake installer,
+// which does not check the specification.
+func testVcfInstanceInput() map[string]interface{} {
+	return map[string]interface{}{
+		"instance_id":                    "sddcId-1001",
+		"skip_esx_thumbprint_validation": true,
+		"version":                        "9.0.0",
+	}
+}
@@ -6,6 +6,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
 )
 
 type IpPoolModel struct {
@@ -49,6 +51,8 @@ type ResourceNetworkPoolModel struct {
 
 type ResourceNetworkPool struct {
 	client *vcf.ClientWithResponses
+	// Network pools cannot be validated, they are not changed in dry-run mode
+	dryRun bool
 }
 
 func (r *ResourceNetworkPool) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
@@ -59,7 +63,15 @@ func (r *ResourceNetworkPool) Configure(ctx context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*api_client.SddcManagerClient).ApiClient
+	client, err := getSddcManagerClient(req.ProviderData)
+	if err != nil {
+		resp.Diagnostics.AddError("SDDC Manager is not configured", err.Error())
+		return
+	}
+	r.client = client.ApiClient
+	if clients, ok := req.ProviderData.(*providerClients); ok {
+		r.dryRun = clients.dryRun
+	}
 }
 
 func (r *ResourceNetworkPool) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -148,8 +160,15 @@ func (r *ResourceNetworkPool) Schema(ctx context.Context, req resource.SchemaReq
 
 func (r *ResourceNetworkPool) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
 	var data ResourceNetworkPoolModel
+	ctx, span := tracing.StartSpan(ctx, "vcf_network_pool.Create", tracing.AttributeResourceType.String("vcf_network_pool"))
+	defer func() { endFrameworkSpan(span, res.Diagnostics, data.Id.ValueString()) }()
 
 	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if r.dryRun {
+		res.Diagnostics.AddError(fmt.Sprintf("Dry run: the creation of network pool %s cannot be validated", data.Name.ValueString()),
+			dryRunNotValidatedDetail)
+		return
+	}
 
 	timeout, diags := data.Timeouts.Create(ctx, 30*time.Minute)
 	res.Diagnostics.Append(diags...)
@@ -199,6 +218,7 @@ func (r *ResourceNetworkPool) Create(ctx context.Context, req resource.CreateReq
 	pool, vcfErr := api_client.GetResponseAs[vcf.NetworkPool](created)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
+		res.Diagnostics.Append(newApiErrorDiagnostic(api_client.NewApiError(vcfErr)))
 		return
 	}
 
@@ -210,12 +230,19 @@ func (r *ResourceNetworkPool) Create(ctx context.Context, req resource.CreateReq
 
 func (r *ResourceNetworkPool) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
 	var data ResourceNetworkPoolModel
+	ctx, span := tracing.StartSpan(ctx, "vcf_network_pool.Read", tracing.AttributeResourceType.String("vcf_network_pool"))
+	defer func() { endFrameworkSpan(span, res.Diagnostics, data.Id.ValueString()) }()
 	res.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	networkPoolPayload, _ := r.client.GetNetworkPoolByIDWithResponse(ctx, data.Id.ValueString())
+	networkPoolPayload, err := r.client.GetNetworkPoolByIDWithResponse(ctx, data.Id.ValueString())
+	if err != nil {
+		res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to read network pool", err.Error()))
+		return
+	}
 	pool, vcfErr := api_client.GetResponseAs[vcf.NetworkPool](networkPoolPayload)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
+		res.Diagnostics.Append(newApiErrorDiagnostic(api_client.NewApiError(vcfErr)))
 		return
 	}
 
@@ -229,12 +256,24 @@ func (r *ResourceNetworkPool) Update(ctx context.Context, req resource.UpdateReq
 
 func (r *ResourceNetworkPool) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
 	var data ResourceNetworkPoolModel
+	ctx, span := tracing.StartSpan(ctx, "vcf_network_pool.Delete", tracing.AttributeResourceType.String("vcf_network_pool"))
+	defer func() { endFrameworkSpan(span, res.Diagnostics, data.Id.ValueString()) }()
 	res.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if r.dryRun {
+		res.Diagnostics.AddError(fmt.Sprintf("Dry run: the deletion of network pool %s cannot be validated", data.Name.ValueString()),
+			dryRunNotValidatedDetail)
+		return
+	}
 
-	networkPoolPayload, _ := r.client.DeleteNetworkPoolWithResponse(ctx, data.Id.ValueString(), nil)
+	networkPoolPayload, err := r.client.DeleteNetworkPoolWithResponse(ctx, data.Id.ValueString(), nil)
+	if err != nil {
+		res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to delete network pool", err.Error()))
+		return
+	}
 	_, vcfErr := api_client.GetResponseAs[vcf.NetworkPool](networkPoolPayload)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
+		res.Diagnostics.Append(newApiErrorDiagnostic(api_client.NewApiError(vcfErr)))
 		return
 	}
 
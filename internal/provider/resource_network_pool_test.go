@@ -10,8 +10,13 @@ import (
 	"log"
 	"testing"
 
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 )
@@ -80,3 +85,44 @@ func testCheckVcfNetworkPoolDestroy(_ *terraform.State) error {
 
 	return nil
 }
+
+// testNetworkPoolState returns the state of a vcf_network_pool with the given ID and no other
+// attributes.
+func testNetworkPoolState(ctx context.Context, r *ResourceNetworkPool, id string) tfsdk.State {
+	schemaRes := &frameworkresource.SchemaResponse{}
+	r.Schema(ctx, frameworkresource.SchemaRequest{}, schemaRes)
+	objectType := schemaRes.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["id"] = tftypes.NewValue(tftypes.String, id)
+	return tfsdk.State{Schema: schemaRes.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+func TestResourceNetworkPoolRead_notFound(t *testing.T) {
+	ctx := context.Background()
+	_, client := testSddcManagerClient(t)
+	r := &ResourceNetworkPool{client: client.ApiClient}
+	state := testNetworkPoolState(ctx, r, "network-pool-1")
+
+	res := &frameworkresource.ReadResponse{State: state}
+	r.Read(ctx, frameworkresource.ReadRequest{State: state}, res)
+
+	// The error of SDDC Manager is reported rather than a failure to parse the response
+	require.True(t, res.Diagnostics.HasError())
+	assert.Contains(t, res.Diagnostics.Errors()[0].Summary(), "network-pool-1")
+}
+
+func TestResourceNetworkPoolDelete_notFound(t *testing.T) {
+	ctx := context.Background()
+	_, client := testSddcManagerClient(t)
+	r := &ResourceNetworkPool{client: client.ApiClient}
+	state := testNetworkPoolState(ctx, r, "network-pool-1")
+
+	res := &frameworkresource.DeleteResponse{State: state}
+	r.Delete(ctx, frameworkresource.DeleteRequest{State: state}, res)
+
+	require.True(t, res.Diagnostics.HasError())
+	assert.Contains(t, res.Diagnostics.Errors()[0].Summary(), "network-pool-1")
+}
@@ -6,7 +6,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"log"
 	"strings"
 	"time"
@@ -18,6 +17,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/vcf"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceUser() *schema.Resource {
@@ -94,38 +94,28 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	if roleName, ok := d.GetOk("role_name"); ok {
 		roleNameVal := roleName.(string)
 
-		roleResult, err := client.GetRolesWithResponse(ctx)
+		role, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+			return client.GetRolesWithResponse(ctx)
+		}, func(role vcf.Role) bool {
+			return role.Name != nil && *role.Name == roleNameVal
+		})
 		if err != nil {
-			return diag.FromErr(err)
+			return validationutils.ConvertVcfErrorToDiag(err)
 		}
-		page, vcfErr := api_client.GetResponseAs[vcf.PageOfRole](roleResult)
-		if vcfErr != nil {
-			api_client.LogError(vcfErr, ctx)
-			return diag.FromErr(errors.New(*vcfErr.Message))
-		}
-
-		roleFound := false
-		for _, role := range *page.Elements {
-			if *role.Name == roleNameVal {
-				user.Role = vcf.RoleReference{Id: *role.Id}
-				roleFound = true
-				break
-			}
-		}
-
-		if !roleFound {
+		if role == nil {
 			return diag.Errorf("role not found: %s", roleNameVal)
 		}
+		user.Role = vcf.RoleReference{Id: *role.Id}
 	}
 
 	created, err := client.AddUsersWithResponse(ctx, []vcf.User{user})
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	page, vcfErr := api_client.GetResponseAs[vcf.PageOfUser](created)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	createdUser := (*page.Elements)[0]
@@ -138,23 +128,18 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	id := d.Id()
 
-	ok, err := client.GetUsersWithResponse(ctx)
+	// Check if the resource with the known id exists
+	user, err := api_client.FindInList(ctx, func() (api_client.Response, error) {
+		return client.GetUsersWithResponse(ctx)
+	}, func(user vcf.User) bool {
+		return user.Id != nil && *user.Id == id
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
-	page, vcfErr := api_client.GetResponseAs[vcf.PageOfUser](ok)
-	if vcfErr != nil {
-		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
-	}
-
-	// Check if the resource with the known id exists
-	for _, user := range *page.Elements {
-		if *user.Id == id {
-			_ = d.Set("api_key", user.ApiKey)
-			_ = d.Set("creation_timestamp", user.CreationTimestamp)
-			return nil
-		}
+	if user != nil {
+		_ = d.Set("api_key", user.ApiKey)
+		_ = d.Set("creation_timestamp", user.CreationTimestamp)
 	}
 
 	return nil
@@ -165,12 +150,12 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interf
 
 	res, err := client.RemoveUserWithResponse(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return validationutils.ConvertVcfErrorToDiag(err)
 	}
 	_, vcfErr := api_client.GetResponseAs[vcf.PageOfUser](res)
 	if vcfErr != nil {
 		api_client.LogError(vcfErr, ctx)
-		return diag.FromErr(errors.New(*vcfErr.Message))
+		return validationutils.ConvertVcfErrorToDiag(api_client.NewApiError(vcfErr))
 	}
 
 	log.Printf("%s: Delete complete", d.Id())
@@ -0,0 +1,214 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// A secret attribute "<name>" has a write-only counterpart "<name>_wo", whose value is never
+// stored in the plan or state, and a "<name>_wo_version" attribute, whose change applies a new
+// value of the write-only attribute.
+const (
+	WriteOnlySuffix        = "_wo"
+	WriteOnlyVersionSuffix = "_wo_version"
+)
+
+// WriteOnlySchema returns the schema of the write-only counterpart of the secret attribute with
+// the given name and description.
+func WriteOnlySchema(name, description string, validateFunc schema.SchemaValidateFunc) *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		WriteOnly: true,
+		Sensitive: true,
+		Description: fmt.Sprintf("%s. Write-only alternative to %s, which is not stored in the plan or state. "+
+			"Requires Terraform 1.11 or later.", strings.TrimSuffix(description, "."), name),
+		ValidateFunc: validateFunc,
+	}
+}
+
+// WriteOnlyVersionSchema returns the schema of the version of the write-only counterpart of the
+// secret attribute with the given name. The resource is replaced when the version changes if
+// forceNew is set, for the resources which cannot apply a new value.
+func WriteOnlyVersionSchema(name string, forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ForceNew: forceNew,
+		Description: fmt.Sprintf("The version of %s%s. Terraform cannot detect changes of write-only values, "+
+			"change the version to apply a new value.", name, WriteOnlySuffix),
+	}
+}
+
+// WithoutWriteOnly returns a copy of a block schema without the write-only attributes and their
+// versions, for data sources which reuse the block schemas of resources in computed blocks.
+func WithoutWriteOnly(resource *schema.Resource) *schema.Resource {
+	result := &schema.Resource{Schema: make(map[string]*schema.Schema, len(resource.Schema))}
+	for name, attribute := range resource.Schema {
+		if attribute.WriteOnly || strings.HasSuffix(name, WriteOnlyVersionSuffix) {
+			continue
+		}
+		if elem, ok := attribute.Elem.(*schema.Resource); ok {
+			copied := *attribute
+			copied.Elem = WithoutWriteOnly(elem)
+			attribute = &copied
+		}
+		result.Schema[name] = attribute
+	}
+	return result
+}
+
+// GetSecret returns the value of a secret attribute of a resource, or the value of its
+// write-only counterpart if the attribute is not set. Write-only values are only available in
+// the create and update operations.
+func GetSecret(d *schema.ResourceData, name string) string {
+	if value, _ := d.Get(name).(string); value != "" {
+		return value
+	}
+	if config := d.GetRawConfig(); !config.IsNull() {
+		return stringValue(config, name+WriteOnlySuffix)
+	}
+	// The raw configuration is missing in unit tests, the value is read from the data instead
+	value, _ := d.Get(name + WriteOnlySuffix).(string)
+	return value
+}
+
+// GetBlockSecret returns the value of a secret attribute of a block returned by
+// GetWithWriteOnly, or the value of its write-only counterpart if the attribute is not set.
+func GetBlockSecret(block map[string]interface{}, name string) string {
+	if value, _ := block[name].(string); value != "" {
+		return value
+	}
+	value, _ := block[name+WriteOnlySuffix].(string)
+	return value
+}
+
+// GetWithWriteOnly returns the value of a top-level attribute or block of a resource like
+// ResourceData.Get, with the values of the write-only attributes nested in it, which are not
+// part of the data, taken from the configuration.
+func GetWithWriteOnly(d *schema.ResourceData, key string) interface{} {
+	value := d.Get(key)
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.Type().IsObjectType() || !config.Type().HasAttribute(key) {
+		return value
+	}
+	return withWriteOnlyValues(value, config.GetAttr(key))
+}
+
+// withWriteOnlyValues sets the write-only attributes of the blocks of a value to their values in
+// the configuration of the value. Blocks are lists, so their elements are in the same order in
+// both.
+func withWriteOnlyValues(value interface{}, config cty.Value) interface{} {
+	if config.IsNull() || !config.IsKnown() {
+		return value
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		if !config.Type().IsListType() && !config.Type().IsTupleType() {
+			return value
+		}
+		for i, element := range config.AsValueSlice() {
+			if i < len(v) {
+				v[i] = withWriteOnlyValues(v[i], element)
+			}
+		}
+	case map[string]interface{}:
+		if !config.Type().IsObjectType() {
+			return value
+		}
+		for name := range config.Type().AttributeTypes() {
+			if strings.HasSuffix(name, WriteOnlySuffix) {
+				if secret := stringValue(config, name); secret != "" {
+					v[name] = secret
+				}
+			} else if nested, ok := v[name]; ok {
+				v[name] = withWriteOnlyValues(nested, config.GetAttr(name))
+			}
+		}
+	}
+	return value
+}
+
+func stringValue(object cty.Value, name string) string {
+	if !object.Type().IsObjectType() || !object.Type().HasAttribute(name) {
+		return ""
+	}
+	value := object.GetAttr(name)
+	if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+		return ""
+	}
+	return value.AsString()
+}
+
+// RequireSecrets validates that exactly one of each of the given secret attributes and its
+// write-only counterpart is set in the configuration of a resource. The path of an attribute
+// nested in blocks joins the names of the blocks and of the attribute with dots, e.g.
+// "sso.domain_password", the attribute is validated in every element of the blocks.
+func RequireSecrets(paths ...string) schema.ValidateRawResourceConfigFunc {
+	return validateSecrets(true, paths)
+}
+
+// ConflictingSecrets validates that at most one of each of the given secret attributes and its
+// write-only counterpart is set in the configuration of a resource. The paths are the same as
+// for RequireSecrets.
+func ConflictingSecrets(paths ...string) schema.ValidateRawResourceConfigFunc {
+	return validateSecrets(false, paths)
+}
+
+func validateSecrets(required bool, paths []string) schema.ValidateRawResourceConfigFunc {
+	return func(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+		for _, path := range paths {
+			resp.Diagnostics = append(resp.Diagnostics,
+				validateSecret(req.RawConfig, cty.Path{}, strings.Split(path, "."), required)...)
+		}
+	}
+}
+
+func validateSecret(config cty.Value, path cty.Path, names []string, required bool) diag.Diagnostics {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(names[0]) {
+		return nil
+	}
+	name := names[0]
+	value := config.GetAttr(name)
+
+	if len(names) > 1 {
+		if value.IsNull() || !value.IsKnown() || !(value.Type().IsListType() || value.Type().IsTupleType()) {
+			return nil
+		}
+		var diags diag.Diagnostics
+		for i, element := range value.AsValueSlice() {
+			diags = append(diags, validateSecret(element, path.GetAttr(name).IndexInt(i), names[1:], required)...)
+		}
+		return diags
+	}
+
+	writeOnlyName := name + WriteOnlySuffix
+	isSet := !value.IsNull()
+	isWriteOnlySet := config.Type().HasAttribute(writeOnlyName) && !config.GetAttr(writeOnlyName).IsNull()
+	switch {
+	case isSet && isWriteOnlySet:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Conflicting secret attributes",
+			Detail:        fmt.Sprintf("Only one of %s and %s can be set.", name, writeOnlyName),
+			AttributePath: path.GetAttr(writeOnlyName),
+		}}
+	case required && !isSet && !isWriteOnlySet:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Missing secret attribute",
+			Detail:        fmt.Sprintf("One of %s and %s must be set.", name, writeOnlyName),
+			AttributePath: path.GetAttr(name),
+		}}
+	}
+	return nil
+}
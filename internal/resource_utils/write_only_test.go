@@ -0,0 +1,97 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWriteOnlyValues(t *testing.T) {
+	value := []interface{}{
+		map[string]interface{}{
+			"name": "sfo-w01-cl01",
+			"host": []interface{}{
+				map[string]interface{}{"id": "host-1", "password": "", "password_wo": ""},
+				map[string]interface{}{"id": "host-2", "password": "S@mpleL0ngP@ss123!", "password_wo": ""},
+			},
+		},
+	}
+	config := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("sfo-w01-cl01"),
+			"host": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"id":          cty.StringVal("host-1"),
+					"password":    cty.NullVal(cty.String),
+					"password_wo": cty.StringVal("Wr1teOnlyP@ss123!"),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"id":          cty.StringVal("host-2"),
+					"password":    cty.StringVal("S@mpleL0ngP@ss123!"),
+					"password_wo": cty.NullVal(cty.String),
+				}),
+			}),
+		}),
+	})
+
+	hosts := withWriteOnlyValues(value, config).([]interface{})[0].(map[string]interface{})["host"].([]interface{})
+
+	assert.Equal(t, "Wr1teOnlyP@ss123!", GetBlockSecret(hosts[0].(map[string]interface{}), "password"))
+	assert.Equal(t, "S@mpleL0ngP@ss123!", GetBlockSecret(hosts[1].(map[string]interface{}), "password"))
+}
+
+func TestValidateSecrets(t *testing.T) {
+	host := func(password, passwordWo cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"password": password, "password_wo": passwordWo})
+	}
+	config := cty.ObjectVal(map[string]cty.Value{
+		"host": cty.ListVal([]cty.Value{
+			host(cty.StringVal("S@mpleL0ngP@ss123!"), cty.NullVal(cty.String)),
+			host(cty.StringVal("S@mpleL0ngP@ss123!"), cty.StringVal("S@mpleL0ngP@ss123!")),
+			host(cty.NullVal(cty.String), cty.NullVal(cty.String)),
+		}),
+	})
+
+	validate := func(f schema.ValidateRawResourceConfigFunc) *schema.ValidateResourceConfigFuncResponse {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		f(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: config}, resp)
+		return resp
+	}
+
+	required := validate(RequireSecrets("host.password")).Diagnostics
+	if assert.Len(t, required, 2) {
+		assert.Equal(t, "Conflicting secret attributes", required[0].Summary)
+		assert.Equal(t, cty.GetAttrPath("host").IndexInt(1).GetAttr("password_wo"), required[0].AttributePath)
+		assert.Equal(t, "Missing secret attribute", required[1].Summary)
+		assert.Equal(t, cty.GetAttrPath("host").IndexInt(2).GetAttr("password"), required[1].AttributePath)
+	}
+
+	conflicting := validate(ConflictingSecrets("host.password")).Diagnostics
+	if assert.Len(t, conflicting, 1) {
+		assert.Equal(t, "Conflicting secret attributes", conflicting[0].Summary)
+	}
+}
+
+func TestWithoutWriteOnly(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"password":            {Type: schema.TypeString, Optional: true},
+			"password_wo":         WriteOnlySchema("password", "Password", nil),
+			"password_wo_version": WriteOnlyVersionSchema("password", false),
+		},
+	}
+
+	result := WithoutWriteOnly(resource)
+
+	assert.Contains(t, result.Schema, "password")
+	assert.NotContains(t, result.Schema, "password_wo")
+	assert.NotContains(t, result.Schema, "password_wo_version")
+	assert.Contains(t, resource.Schema, "password_wo")
+}
@@ -20,9 +20,11 @@ func getCredentialsSchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"password": {
 					Type:         schema.TypeString,
-					Required:     true,
+					Optional:     true,
 					ValidateFunc: validation.ValidatePassword,
 				},
+				"password_wo":         utils.WriteOnlySchema("password", "Password", validation.ValidatePassword),
+				"password_wo_version": utils.WriteOnlyVersionSchema("password", true),
 				"username": {
 					Type:     schema.TypeString,
 					Required: true,
@@ -37,7 +39,7 @@ func getCredentialsFromSchema(rawData []interface{}) *installer.SddcCredentials
 		return nil
 	}
 	data := rawData[0].(map[string]interface{})
-	password := data["password"].(string)
+	password := utils.GetBlockSecret(data, "password")
 	username := utils.ToStringPointer(data["username"])
 
 	credentialsBinding := &installer.SddcCredentials{
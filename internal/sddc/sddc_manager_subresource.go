@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vcf-sdk-go/installer"
 
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -31,18 +32,24 @@ func GetSddcManagerSchema() *schema.Schema {
 					Optional:     true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"local_user_password_wo":         utils.WriteOnlySchema("local_user_password", "The password for the break glass user admin@local", validation_utils.ValidatePassword),
+				"local_user_password_wo_version": utils.WriteOnlyVersionSchema("local_user_password", true),
 				"root_user_password": {
 					Type:         schema.TypeString,
-					Description:  "The password for the root user",
-					Required:     true,
+					Description:  "The password for the root user. Either root_user_password or root_user_password_wo is required",
+					Optional:     true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"root_user_password_wo":         utils.WriteOnlySchema("root_user_password", "The password for the root user", validation_utils.ValidatePassword),
+				"root_user_password_wo_version": utils.WriteOnlyVersionSchema("root_user_password", true),
 				"ssh_password": {
 					Type:         schema.TypeString,
-					Description:  "The password for the vcf user (ssh connections only)",
-					Required:     true,
+					Description:  "The password for the vcf user (ssh connections only). Either ssh_password or ssh_password_wo is required",
+					Optional:     true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"ssh_password_wo":         utils.WriteOnlySchema("ssh_password", "The password for the vcf user (ssh connections only)", validation_utils.ValidatePassword),
+				"ssh_password_wo_version": utils.WriteOnlyVersionSchema("ssh_password", true),
 			},
 		},
 	}
@@ -56,9 +63,9 @@ func GetSddcManagerSpecFromSchema(rawData []interface{}) *installer.SddcManagerS
 	}
 	data := rawData[0].(map[string]interface{})
 	hostname := data["hostname"].(string)
-	localUserPassword := data["local_user_password"].(string)
-	rootUserPassword := data["root_user_password"].(string)
-	sshPassword := data["ssh_password"].(string)
+	localUserPassword := utils.GetBlockSecret(data, "local_user_password")
+	rootUserPassword := utils.GetBlockSecret(data, "root_user_password")
+	sshPassword := utils.GetBlockSecret(data, "ssh_password")
 
 	sddcManagerSpec := &installer.SddcManagerSpec{
 		Hostname: hostname,
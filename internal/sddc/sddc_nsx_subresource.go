@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/vcf-sdk-go/installer"
 )
@@ -26,11 +27,13 @@ func GetNsxSpecSchema() *schema.Schema {
 				},
 				"root_nsx_manager_password": {
 					Type:         schema.TypeString,
-					Description:  "NSX Manager root password. Password should have 1) At least eight characters, 2) At least one lower-case letter, 3) At least one upper-case letter 4) At least one digit 5) At least one special character, 6) At least five different characters , 7) No dictionary words, 6) No palindromes",
-					Required:     true,
+					Description:  "NSX Manager root password. Password should have 1) At least eight characters, 2) At least one lower-case letter, 3) At least one upper-case letter 4) At least one digit 5) At least one special character, 6) At least five different characters , 7) No dictionary words, 6) No palindromes. Either root_nsx_manager_password or root_nsx_manager_password_wo is required",
+					Optional:     true,
 					Sensitive:    true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"root_nsx_manager_password_wo":         utils.WriteOnlySchema("root_nsx_manager_password", "NSX Manager root password", validation_utils.ValidatePassword),
+				"root_nsx_manager_password_wo_version": utils.WriteOnlyVersionSchema("root_nsx_manager_password", true),
 				"ip_address_pool": {
 					Type:        schema.TypeList,
 					Description: "NSX IP address pool specification",
@@ -45,6 +48,8 @@ func GetNsxSpecSchema() *schema.Schema {
 					Sensitive:    true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"nsx_admin_password_wo":         utils.WriteOnlySchema("nsx_admin_password", "NSX admin password", validation_utils.ValidatePassword),
+				"nsx_admin_password_wo_version": utils.WriteOnlyVersionSchema("nsx_admin_password", true),
 				"nsx_audit_password": {
 					Type:         schema.TypeString,
 					Description:  "NSX audit password. The password must be at least 12 characters long. Must contain at-least 1 uppercase, 1 lowercase, 1 special character and 1 digit. In addition, a character cannot be repeated 3 or more times consecutively.",
@@ -52,6 +57,8 @@ func GetNsxSpecSchema() *schema.Schema {
 					Sensitive:    true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"nsx_audit_password_wo":         utils.WriteOnlySchema("nsx_audit_password", "NSX audit password", validation_utils.ValidatePassword),
+				"nsx_audit_password_wo_version": utils.WriteOnlyVersionSchema("nsx_audit_password", true),
 				"nsx_manager_size": {
 					Type:         schema.TypeString,
 					Description:  "NSX-T Manager size. One among: medium, large",
@@ -92,10 +99,10 @@ func GetNsxSpecFromSchema(rawData []interface{}) *installer.SddcNsxtSpec {
 		return nil
 	}
 	data := rawData[0].(map[string]interface{})
-	nsxAdminPassword := data["nsx_admin_password"].(string)
-	nsxAuditPassword := data["nsx_audit_password"].(string)
+	nsxAdminPassword := utils.GetBlockSecret(data, "nsx_admin_password")
+	nsxAuditPassword := utils.GetBlockSecret(data, "nsx_audit_password")
 	nsxManagerSize := data["nsx_manager_size"].(string)
-	rootNsxManagerPassword := data["root_nsx_manager_password"].(string)
+	rootNsxManagerPassword := utils.GetBlockSecret(data, "root_nsx_manager_password")
 	transportVlanID := int32(data["transport_vlan_id"].(int))
 	vipFqdn := data["vip_fqdn"].(string)
 
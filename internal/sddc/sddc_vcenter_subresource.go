@@ -7,6 +7,7 @@ package sddc
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/vcf-sdk-go/installer"
 )
@@ -23,11 +24,13 @@ func GetVcenterSchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"root_vcenter_password": {
 					Type:         schema.TypeString,
-					Description:  "vCenter root password. The password must be between 8 characters and 20 characters long. It must also contain at least one uppercase and lowercase letter, one number, and one character from '! \" # $ % & ' ( ) * + , - . / : ; < = > ? @ [ \\ ] ^ _ ` { &Iota; } ~' and all characters must be ASCII. Space is not allowed in password.",
-					Required:     true,
+					Description:  "vCenter root password. The password must be between 8 characters and 20 characters long. It must also contain at least one uppercase and lowercase letter, one number, and one character from '! \" # $ % & ' ( ) * + , - . / : ; < = > ? @ [ \\ ] ^ _ ` { &Iota; } ~' and all characters must be ASCII. Space is not allowed in password. Either root_vcenter_password or root_vcenter_password_wo is required",
+					Optional:     true,
 					Sensitive:    true,
 					ValidateFunc: validation_utils.ValidatePassword,
 				},
+				"root_vcenter_password_wo":         utils.WriteOnlySchema("root_vcenter_password", "vCenter root password", validation_utils.ValidatePassword),
+				"root_vcenter_password_wo_version": utils.WriteOnlyVersionSchema("root_vcenter_password", true),
 				"ssl_thumbprint": {
 					Type:        schema.TypeString,
 					Description: "vCenter Server SSL thumbprint (SHA256)",
@@ -60,7 +63,7 @@ func GetVcenterSpecFromSchema(rawData []interface{}) *installer.SddcVcenterSpec
 		return nil
 	}
 	data := rawData[0].(map[string]interface{})
-	rootVcenterPassword := data["root_vcenter_password"].(string)
+	rootVcenterPassword := utils.GetBlockSecret(data, "root_vcenter_password")
 	sslThumbprint := data["ssl_thumbprint"].(string)
 	storageSize := data["storage_size"].(string)
 	vcenterHostname := data["vcenter_hostname"].(string)
@@ -28,6 +28,8 @@ func GetVcfAutomationSchema() *schema.Schema {
 					Optional:    true,
 					Sensitive:   true,
 				},
+				"admin_user_password_wo":         utils.WriteOnlySchema("admin_user_password", "Administrator password", nil),
+				"admin_user_password_wo_version": utils.WriteOnlyVersionSchema("admin_user_password", true),
 				"internal_cluster_cidr": {
 					Type:        schema.TypeString,
 					Description: "Internal Cluster CIDR. One among: 198.18.0.0/15, 240.0.0.0/15, 250.0.0.0/15",
@@ -58,8 +60,8 @@ func GetVcfAutomationSpecFromSchema(rawData []interface{}) *installer.VcfAutomat
 	data := rawData[0].(map[string]interface{})
 
 	var adminPassword *string
-	if data["admin_user_password"].(string) != "" {
-		adminPassword = utils.ToPointer[string](data["admin_user_password"])
+	if password := utils.GetBlockSecret(data, "admin_user_password"); password != "" {
+		adminPassword = &password
 	}
 
 	var nodePrefix *string
@@ -29,6 +29,8 @@ func GetVcfOperationsCollectorSchema() *schema.Schema {
 					Optional:    true,
 					Sensitive:   true,
 				},
+				"root_user_password_wo":         utils.WriteOnlySchema("root_user_password", "root password", nil),
+				"root_user_password_wo_version": utils.WriteOnlyVersionSchema("root_user_password", true),
 				"appliance_size": {
 					Type:         schema.TypeString,
 					Description:  "Appliance size",
@@ -47,8 +49,8 @@ func GetVcfOperationsCollectorSpecFromSchema(rawData []interface{}) *installer.V
 	data := rawData[0].(map[string]interface{})
 
 	var rootPassword *string
-	if data["root_user_password"].(string) != "" {
-		rootPassword = utils.ToPointer[string](data["root_user_password"])
+	if password := utils.GetBlockSecret(data, "root_user_password"); password != "" {
+		rootPassword = &password
 	}
 
 	var applianceSize *string
@@ -28,12 +28,16 @@ func GetVcfOperationsFleetManagementSchema() *schema.Schema {
 					Optional:    true,
 					Sensitive:   true,
 				},
+				"root_user_password_wo":         utils.WriteOnlySchema("root_user_password", "root password", nil),
+				"root_user_password_wo_version": utils.WriteOnlyVersionSchema("root_user_password", true),
 				"admin_user_password": {
 					Type:        schema.TypeString,
-					Description: "root password",
+					Description: "Administrator password",
 					Optional:    true,
 					Sensitive:   true,
 				},
+				"admin_user_password_wo":         utils.WriteOnlySchema("admin_user_password", "Administrator password", nil),
+				"admin_user_password_wo_version": utils.WriteOnlyVersionSchema("admin_user_password", true),
 			},
 		},
 	}
@@ -46,13 +50,13 @@ func GetVcfOperationsFleetManagementSpecFromSchema(rawData []interface{}) *insta
 	data := rawData[0].(map[string]interface{})
 
 	var rootPassword *string
-	if data["root_user_password"].(string) != "" {
-		rootPassword = utils.ToPointer[string](data["root_user_password"])
+	if password := utils.GetBlockSecret(data, "root_user_password"); password != "" {
+		rootPassword = &password
 	}
 
 	var adminPassword *string
-	if data["admin_user_password"].(string) != "" {
-		adminPassword = utils.ToPointer[string](data["admin_user_password"])
+	if password := utils.GetBlockSecret(data, "admin_user_password"); password != "" {
+		adminPassword = &password
 	}
 
 	spec := &installer.VcfOperationsFleetManagementSpec{
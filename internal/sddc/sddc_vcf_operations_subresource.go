@@ -24,6 +24,8 @@ func GetVcfOperationsSchema() *schema.Schema {
 					Optional:    true,
 					Sensitive:   true,
 				},
+				"admin_user_password_wo":         utils.WriteOnlySchema("admin_user_password", "Administrator password", nil),
+				"admin_user_password_wo_version": utils.WriteOnlyVersionSchema("admin_user_password", true),
 				"appliance_size": {
 					Type:         schema.TypeString,
 					Description:  "Appliance size",
@@ -58,6 +60,8 @@ func getNodesSchema() *schema.Schema {
 					Optional:    true,
 					Sensitive:   true,
 				},
+				"root_user_password_wo":         utils.WriteOnlySchema("root_user_password", "root password", nil),
+				"root_user_password_wo_version": utils.WriteOnlyVersionSchema("root_user_password", true),
 				"type": {
 					Type:         schema.TypeString,
 					Description:  "Type of the node",
@@ -76,8 +80,8 @@ func GetVcfOperationsSpecFromSchema(rawData []interface{}) *installer.VcfOperati
 	data := rawData[0].(map[string]interface{})
 
 	var adminPassword *string
-	if data["admin_user_password"].(string) != "" {
-		adminPassword = utils.ToPointer[string](data["admin_user_password"])
+	if password := utils.GetBlockSecret(data, "admin_user_password"); password != "" {
+		adminPassword = &password
 	}
 
 	var applianceSize *string
@@ -107,7 +111,7 @@ func getNodesFromSchema(data []interface{}) []installer.VcfOperationsNode {
 			nodeData := d.(map[string]interface{})
 			node := installer.VcfOperationsNode{
 				Hostname:         nodeData["hostname"].(string),
-				RootUserPassword: utils.ToPointer[string](nodeData["root_user_password"].(string)),
+				RootUserPassword: utils.ToPointer[string](utils.GetBlockSecret(nodeData, "root_user_password")),
 				Type:             utils.ToPointer[string](nodeData["type"].(string)),
 			}
 
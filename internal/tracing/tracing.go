@@ -0,0 +1,116 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tracing records OpenTelemetry traces of the resource operations, the API requests and
+// the tasks the provider waits for. Tracing is disabled unless the VCF_TRACE_EXPORTER
+// environment variable is set, spans are then exported to an OTLP collector or to a file.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/version"
+)
+
+const (
+	// ExporterOtlp exports the spans to an OTLP collector over HTTP. The collector is configured
+	// with the standard OTEL_EXPORTER_OTLP_* environment variables, localhost:4318 by default.
+	ExporterOtlp = "otlp"
+	// ExporterFile writes the spans as JSON lines to the file set in VCF_TRACE_FILE.
+	ExporterFile = "file"
+
+	tracerName = "github.com/vmware/terraform-provider-vcf"
+)
+
+// Attributes recorded on the spans.
+const (
+	AttributeResourceType = attribute.Key("vcf.resource.type")
+	AttributeResourceId   = attribute.Key("vcf.resource.id")
+	AttributeTaskId       = attribute.Key("vcf.task.id")
+)
+
+// Start enables tracing as configured by the environment and returns a function which flushes
+// the spans recorded so far when the provider exits. It does nothing if tracing is not enabled.
+func Start(ctx context.Context) (func(context.Context) error, error) {
+	exporterName := os.Getenv(constants.VcfTraceExporter)
+	if exporterName == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var spanProcessor sdktrace.SpanProcessor
+	var closeFile func() error
+	switch exporterName {
+	case ExporterOtlp:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+		}
+		spanProcessor = sdktrace.NewBatchSpanProcessor(exporter)
+	case ExporterFile:
+		path := os.Getenv(constants.VcfTraceFile)
+		if path == "" {
+			return nil, fmt.Errorf("%s must be set to export traces to a file", constants.VcfTraceFile)
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to create the file trace exporter: %w", err)
+		}
+		// Spans are written as they end, the provider may be stopped without shutting down
+		spanProcessor = sdktrace.NewSimpleSpanProcessor(exporter)
+		closeFile = file.Close
+	default:
+		return nil, fmt.Errorf("unknown %s %q, expected %q or %q",
+			constants.VcfTraceExporter, exporterName, ExporterOtlp, ExporterFile)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(spanProcessor),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", constants.ProviderName),
+			attribute.String("service.version", version.ProviderVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeFile != nil {
+			err = errors.Join(err, closeFile())
+		}
+		return err
+	}, nil
+}
+
+// StartSpan starts a span with the tracer of the provider. The span is not recorded unless
+// tracing is enabled.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan ends a span, with an error status if the operation of the span has failed.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,66 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestStart_disabled(t *testing.T) {
+	t.Setenv(constants.VcfTraceExporter, "")
+
+	shutdown, err := Start(context.Background())
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	if err = shutdown(context.Background()); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+}
+
+func TestStart_file(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv(constants.VcfTraceExporter, ExporterFile)
+	t.Setenv(constants.VcfTraceFile, path)
+
+	shutdown, err := Start(context.Background())
+	if err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+	_, span := StartSpan(context.Background(), "vcf_domain.Create", AttributeResourceId.String("domain-1"))
+	EndSpan(span, errors.New("task failed"))
+	if err = shutdown(context.Background()); err != nil {
+		t.Fatal("received an unexpected error", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`"Name":"vcf_domain.Create"`, `"domain-1"`, `"task failed"`} {
+		if !strings.Contains(string(data), expected) {
+			t.Fatalf("expected the trace file to contain %s, got %s", expected, data)
+		}
+	}
+}
+
+func TestStart_unknownExporter(t *testing.T) {
+	t.Setenv(constants.VcfTraceExporter, "jaeger")
+
+	if _, err := Start(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 )
 
 func ValidatePassword(v interface{}, k string) (warnings []string, errors []error) {
@@ -156,35 +158,31 @@ func ValidateCidrIPv4AddressSchema(i interface{}, k string) (_ []string, errors
 	}
 }
 
+// ConvertVcfErrorToDiag converts an error to diagnostics. Errors returned by the SDDC Manager
+// or installer API, either as a *vcf.Error or wrapped in an api_client.ApiError, keep their
+// remediation, causes and reference token in the detail of the diagnostic.
 func ConvertVcfErrorToDiag(err interface{}) diag.Diagnostics {
-	if err == nil {
+	switch err := err.(type) {
+	case nil:
 		return nil
+	case *vcf.Error:
+		if err == nil {
+			return nil
+		}
+		return ConvertVcfErrorToDiag(api_client.NewApiError(err))
+	case error:
+		var apiErr *api_client.ApiError
+		if !errors.As(err, &apiErr) {
+			return diag.FromErr(err)
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   apiErr.Detail(),
+		}}
+	default:
+		return diag.Errorf("%v", err)
 	}
-
-	if vcfError, ok := err.(*vcf.Error); ok {
-		return convertVcfErrorsToDiagErrors(vcfError)
-	}
-
-	return diag.FromErr(err.(error))
-}
-
-func convertVcfErrorsToDiagErrors(err *vcf.Error) []diag.Diagnostic {
-	var result []diag.Diagnostic
-
-	var errorDetail string
-	if err.RemediationMessage != nil && IsEmpty(*err.ReferenceToken) {
-		errorDetail = *err.RemediationMessage
-	} else {
-		errorDetail = fmt.Sprintf("look for reference token %q in service logs", *err.ReferenceToken)
-	}
-
-	result = append(result, diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  *err.Message,
-		Detail:   errorDetail,
-	})
-
-	return result
 }
 
 func HasValidationFailed(validationResult *vcf.Validation) bool {
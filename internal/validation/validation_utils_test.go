@@ -5,9 +5,15 @@
 package validation
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/vmware/vcf-sdk-go/vcf"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 )
 
 func TestValidatePassword(t *testing.T) {
@@ -227,3 +233,24 @@ func TestIsEmpty(t *testing.T) {
 		}
 	})
 }
+
+func TestConvertVcfErrorToDiag(t *testing.T) {
+	referenceToken := "K1FMMB"
+	message := "Domain creation failed"
+	diags := ConvertVcfErrorToDiag(fmt.Errorf("failed to create domain: %w",
+		api_client.NewApiError(&vcf.Error{Message: &message, ReferenceToken: &referenceToken})))
+
+	if len(diags) != 1 || diags[0].Summary != "failed to create domain: Domain creation failed" {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, referenceToken) {
+		t.Errorf("expected the reference token in the detail: %s", diags[0].Detail)
+	}
+
+	if diags = ConvertVcfErrorToDiag(errors.New("plain error")); diags[0].Summary != "plain error" || diags[0].Detail != "" {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+	if diags = ConvertVcfErrorToDiag(nil); diags != nil {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vcf-sdk-go/vcf"
 
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -45,11 +46,14 @@ func VCSubresourceSchema() *schema.Resource {
 			},
 			"root_password": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Sensitive:    true,
-				Description:  "root password for the vCenter Server Appliance (8-20 characters)",
+				Description:  "root password for the vCenter Server Appliance (8-20 characters). Either root_password or root_password_wo is required",
 				ValidateFunc: validationUtils.ValidatePassword,
 			},
+			"root_password_wo": utils.WriteOnlySchema("root_password",
+				"root password for the vCenter Server Appliance (8-20 characters)", validationUtils.ValidatePassword),
+			"root_password_wo_version": utils.WriteOnlyVersionSchema("root_password", false),
 			"vm_size": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -106,9 +110,9 @@ func TryConvertToVcenterSpec(object map[string]interface{}) (*vcf.VcenterSpec, e
 	if len(datacenterName) == 0 {
 		return nil, fmt.Errorf("cannot convert to VcenterSpec, datacenter_name is required")
 	}
-	rootPassword := object["root_password"].(string)
+	rootPassword := utils.GetBlockSecret(object, "root_password")
 	if len(rootPassword) == 0 {
-		return nil, fmt.Errorf("cannot convert to VcenterSpec, root_password is required")
+		return nil, fmt.Errorf("cannot convert to VcenterSpec, root_password or root_password_wo is required")
 	}
 	ipAddress := object["ip_address"].(string)
 	if len(ipAddress) == 0 {
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 
 	"github.com/vmware/terraform-provider-vcf/internal/provider"
+	"github.com/vmware/terraform-provider-vcf/internal/tracing"
 )
 
 func main() {
@@ -25,6 +26,11 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	shutdownTracing, err := tracing.Start(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	upgradedSdkServer, err := tf5to6server.UpgradeServer(
 		ctx,
 		provider.Provider().GRPCProvider,
@@ -59,6 +65,10 @@ func main() {
 		serveOpts...,
 	)
 
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("failed to export the traces: %s", shutdownErr)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}